@@ -0,0 +1,38 @@
+package blobstore
+
+import (
+	"errors"
+	"io"
+	"regexp"
+)
+
+var (
+	ErrNotFound   = errors.New("Blob doesn't exists")
+	ErrInvalidKey = errors.New("Invalid blob key")
+)
+
+var validKey = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*$`)
+
+type BlobInfo struct {
+	ContentType string
+	Size        int64
+}
+
+// Store persists opaque binary blobs, such as uploaded images, under a flat key space
+type Store interface {
+	// Put stores the data under the key, replacing any existing blob, and returns the number of bytes written
+	Put(key string, contentType string, data io.Reader) (int64, error)
+	// Get opens the blob stored under the key, the caller has to close the returned reader
+	Get(key string) (io.ReadCloser, *BlobInfo, error)
+	// Stat returns the info of the blob stored under the key without reading it
+	Stat(key string) (*BlobInfo, error)
+	Delete(key string) error
+}
+
+// ValidateKey makes sure a key can be safely used by every Store implementation
+func ValidateKey(key string) error {
+	if !validKey.MatchString(key) {
+		return ErrInvalidKey
+	}
+	return nil
+}
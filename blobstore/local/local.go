@@ -0,0 +1,113 @@
+package local
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/twitter/blobstore"
+)
+
+// content type of every blob is kept next to it in a file with this suffix
+const contentTypeSuffix = ".content-type"
+
+type local struct {
+	rootDir string
+}
+
+func (l *local) blobPath(key string) string {
+	return filepath.Join(l.rootDir, key)
+}
+
+func (l *local) Put(key string, contentType string, data io.Reader) (int64, error) {
+	if err := blobstore.ValidateKey(key); err != nil {
+		return 0, err
+	}
+	// write to a temporary file first so readers never see a partially written blob
+	tmpFile, err := os.CreateTemp(l.rootDir, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmpFile.Name())
+	written, err := io.Copy(tmpFile, data)
+	if err != nil {
+		tmpFile.Close()
+		return 0, err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(l.blobPath(key)+contentTypeSuffix, []byte(contentType), 0644); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmpFile.Name(), l.blobPath(key)); err != nil {
+		return 0, err
+	}
+	return written, nil
+}
+
+func (l *local) Stat(key string) (*blobstore.BlobInfo, error) {
+	if err := blobstore.ValidateKey(key); err != nil {
+		return nil, err
+	}
+	fileInfo, err := os.Stat(l.blobPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, blobstore.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	contentType, err := os.ReadFile(l.blobPath(key) + contentTypeSuffix)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if len(contentType) == 0 {
+		contentType = []byte("application/octet-stream")
+	}
+	return &blobstore.BlobInfo{
+		ContentType: string(contentType),
+		Size:        fileInfo.Size(),
+	}, nil
+}
+
+func (l *local) Get(key string) (io.ReadCloser, *blobstore.BlobInfo, error) {
+	blobInfo, err := l.Stat(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	blobFile, err := os.Open(l.blobPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, blobstore.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return blobFile, blobInfo, nil
+}
+
+func (l *local) Delete(key string) error {
+	if err := blobstore.ValidateKey(key); err != nil {
+		return err
+	}
+	err := os.Remove(l.blobPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return blobstore.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	os.Remove(l.blobPath(key) + contentTypeSuffix)
+	return nil
+}
+
+// New returns a blob store keeping every blob as a file inside rootDir, creating the directory if needed
+func New(rootDir string) (blobstore.Store, error) {
+	if err := os.MkdirAll(rootDir, 0755); err != nil {
+		return nil, err
+	}
+	return &local{
+		rootDir: rootDir,
+	}, nil
+}
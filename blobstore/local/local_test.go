@@ -0,0 +1,50 @@
+package local
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/twitter/blobstore"
+)
+
+func Test_local_PutGet(t *testing.T) {
+	test_store, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("Error in creating local blob store: %+v\n", err)
+	}
+	written, err := test_store.Put("image-1", "image/png", strings.NewReader("not really a png"))
+	if err != nil {
+		t.Errorf("Error in putting blob: %+v\n", err)
+	}
+	if written != int64(len("not really a png")) {
+		t.Errorf("Unexpected number of bytes written: %d\n", written)
+	}
+	reader, blob_info, err := test_store.Get("image-1")
+	if err != nil {
+		t.Fatalf("Error in getting blob: %+v\n", err)
+	}
+	defer reader.Close()
+	data, _ := io.ReadAll(reader)
+	if string(data) != "not really a png" {
+		t.Errorf("Blob content doesn't match: %s\n", data)
+	}
+	if blob_info.ContentType != "image/png" || blob_info.Size != written {
+		t.Errorf("Unexpected blob info: %+v\n", blob_info)
+	}
+	if err := test_store.Delete("image-1"); err != nil {
+		t.Errorf("Error in deleting blob: %+v\n", err)
+	}
+	if _, err := test_store.Stat("image-1"); err != blobstore.ErrNotFound {
+		t.Errorf("Deleted blob still exists: %+v\n", err)
+	}
+}
+
+func Test_local_InvalidKey(t *testing.T) {
+	test_store, _ := New(t.TempDir())
+	for _, key := range []string{"", "../escape", "a/b", ".hidden"} {
+		if _, err := test_store.Put(key, "image/png", strings.NewReader("")); err != blobstore.ErrInvalidKey {
+			t.Errorf("Put with key %q returned: %+v\n", key, err)
+		}
+	}
+}
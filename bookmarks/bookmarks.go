@@ -0,0 +1,90 @@
+package bookmarks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 50
+)
+
+// Service keeps the private list of bookmarked posts of every user
+type Service interface {
+	AddBookmark(ctx context.Context, user *models.User, postId string) error
+	RemoveBookmark(ctx context.Context, user *models.User, postId string) error
+	// ListBookmarks returns a page of the user's bookmarked posts, most recently bookmarked first,
+	// and the total number of bookmarks
+	ListBookmarks(ctx context.Context, user *models.User, offset int, limit int) ([]*models.Post, int, error)
+	// RemovePost drops a deleted post from the bookmarks of every user
+	RemovePost(ctx context.Context, post *models.Post) error
+}
+
+type BookmarkService struct {
+	db storage.Storage
+}
+
+func (bs *BookmarkService) AddBookmark(ctx context.Context, user *models.User, postId string) error {
+	return bs.db.BookmarkStore().AddBookmark(ctx, user.UserName, postId, time.Now())
+}
+
+func (bs *BookmarkService) RemoveBookmark(ctx context.Context, user *models.User, postId string) error {
+	return bs.db.BookmarkStore().RemoveBookmark(ctx, user.UserName, postId)
+}
+
+func (bs *BookmarkService) ListBookmarks(ctx context.Context, user *models.User, offset int, limit int) ([]*models.Post, int, error) {
+	postIds, err := bs.db.BookmarkStore().GetBookmarks(ctx, user.UserName)
+	if err != nil {
+		return nil, 0, err
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	if offset > len(postIds) {
+		offset = len(postIds)
+	}
+	end := offset + limit
+	if end > len(postIds) {
+		end = len(postIds)
+	}
+	// only the posts of the page are loaded
+	total := len(postIds)
+	bookmarked := make([]*models.Post, 0, end-offset)
+	for _, postId := range postIds[offset:end] {
+		post, err := bs.db.PostStore().GetPost(ctx, postId)
+		if errors.Is(err, storage.ErrPostNotFound) {
+			// the post was deleted without its bookmarks being cleaned up, drop the stale bookmark
+			if err := bs.db.BookmarkStore().RemoveBookmark(ctx, user.UserName, postId); err != nil {
+				return nil, 0, err
+			}
+			total--
+			continue
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		bookmarked = append(bookmarked, post)
+	}
+	return bookmarked, total, nil
+}
+
+func (bs *BookmarkService) RemovePost(ctx context.Context, post *models.Post) error {
+	return bs.db.BookmarkStore().RemovePost(ctx, post.PostID)
+}
+
+func New(db storage.Storage) Service {
+	return &BookmarkService{
+		db: db,
+	}
+}
@@ -0,0 +1,67 @@
+package bookmarks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+	"github.com/twitter/storage/memory"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// unreachablePosts is a storage whose posts can't be read, like etcd timing out
+type unreachablePosts struct {
+	storage.Storage
+}
+
+func (u unreachablePosts) PostStore() storage.PostStore {
+	return failingPostStore{u.Storage.PostStore()}
+}
+
+type failingPostStore struct {
+	storage.PostStore
+}
+
+func (failingPostStore) GetPost(ctx context.Context, postId string) (*models.Post, error) {
+	return nil, errors.New("context deadline exceeded")
+}
+
+func TestListBookmarks(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	defer db.Close()
+	test_service := New(db)
+	user := &models.User{UserName: "reader"}
+	var post_ids []string
+	for i := 0; i < 3; i++ {
+		post, _ := db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "writer", Content: "post", PostedAt: timestamppb.Now()})
+		if err := test_service.AddBookmark(ctx, user, post.PostID); err != nil {
+			t.Fatalf("Error in bookmarking post: %+v\n", err)
+		}
+		post_ids = append(post_ids, post.PostID)
+	}
+
+	page, total, err := test_service.ListBookmarks(ctx, user, 1, 1)
+	if err != nil || total != 3 || len(page) != 1 || page[0].PostID != post_ids[1] {
+		t.Errorf("Unexpected page: %+v %+v %+v\n", page, total, err)
+	}
+
+	if _, _, err := New(unreachablePosts{db}).ListBookmarks(ctx, user, 0, 10); err == nil {
+		t.Error("Error in reading the posts not returned")
+	}
+	if _, total, _ := test_service.ListBookmarks(ctx, user, 0, 10); total != 3 {
+		t.Errorf("Bookmarks dropped when the posts couldn't be read: %+v\n", total)
+	}
+
+	deleted, _ := db.PostStore().GetPost(ctx, post_ids[2])
+	db.PostStore().DeletePost(ctx, deleted)
+	page, total, err = test_service.ListBookmarks(ctx, user, 0, 10)
+	if err != nil || total != 2 || len(page) != 2 {
+		t.Errorf("Bookmark of the deleted post not dropped: %+v %+v %+v\n", page, total, err)
+	}
+	if ids, _ := db.BookmarkStore().GetBookmarks(ctx, user.UserName); len(ids) != 2 {
+		t.Errorf("Stale bookmark still stored: %+v\n", ids)
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/twitter/twitter"
+)
+
+// runImpressionFlusher writes the recorded impressions to the storage every interval, and once more when
+// ctx is done
+func runImpressionFlusher(ctx context.Context, twtServer *twitter.Server, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// ctx is already done, the last flush must not be cancelled with it
+			if err := twtServer.ImpressionService.Flush(context.Background()); err != nil {
+				slog.Error("Unable to flush impressions", "err", err)
+			}
+			return
+		case <-ticker.C:
+			if err := twtServer.ImpressionService.Flush(ctx); err != nil {
+				slog.Error("Unable to flush impressions", "err", err)
+			}
+		}
+	}
+}
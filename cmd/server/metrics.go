@@ -0,0 +1,25 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// serveMetrics exposes the metrics of the registry at /metrics on the address, until the returned server is
+// shut down
+func serveMetrics(address string, registry *prometheus.Registry) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}))
+	metricsServer := &http.Server{Addr: address, Handler: mux}
+	slog.Info("Serving metrics", "address", address+"/metrics")
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("Unable to serve metrics", "err", err)
+		}
+	}()
+	return metricsServer
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/twitter/twitter"
+)
+
+const publisherElection = "scheduled-post-publisher"
+
+// runPublisher publishes due scheduled posts for as long as this replica is the elected
+// publisher, and campaigns again whenever the leadership is lost, until ctx is done
+func runPublisher(ctx context.Context, twtServer *twitter.Server, interval time.Duration) {
+	for ctx.Err() == nil {
+		lost, err := twtServer.StorageService.Elector().Campaign(ctx, publisherElection)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.Error("Unable to campaign for scheduled post publisher", "err", err)
+				sleep(ctx, interval)
+			}
+			continue
+		}
+		slog.Info("Elected as the scheduled post publisher")
+		publishWhileLeader(ctx, twtServer, lost, interval)
+		slog.Info("No longer the scheduled post publisher")
+	}
+}
+
+func publishWhileLeader(ctx context.Context, twtServer *twitter.Server, lost <-chan struct{}, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lost:
+			return
+		case <-ticker.C:
+			if err := twtServer.PublishDuePosts(ctx); err != nil {
+				slog.Error("Unable to publish scheduled posts", "err", err)
+			}
+		}
+	}
+}
+
+func sleep(ctx context.Context, duration time.Duration) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/twitter/ratelimit"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// newRateLimiter creates the limiter configured by rateLimitStore, nil when the RPCs aren't limited. The buckets are
+// shared by the servers through etcd, so it needs the etcd client of the raft storage.
+func newRateLimiter(config *Config, etcdClient *clientv3.Client, identify ratelimit.Identify) *ratelimit.Limiter {
+	var store ratelimit.Store
+	switch config.RateLimitStore {
+	case "none":
+		return nil
+	case "memory":
+		store = ratelimit.NewMemoryStore()
+	case "etcd":
+		if etcdClient == nil {
+			fatal("The etcd rate limits need the raft memory type", "memoryType", config.MemoryType)
+		}
+		store = ratelimit.NewEtcdStore(etcdClient)
+	default:
+		fatal("Unrecognized rate limit store supplied", "rateLimitStore", config.RateLimitStore)
+	}
+	if config.RateLimitReadsPerSecond <= 0 {
+		fatal("rateLimitReadsPerSecond has to be positive", "rateLimitReadsPerSecond", config.RateLimitReadsPerSecond)
+	}
+	if config.RateLimitReadsBurst <= 0 {
+		fatal("rateLimitReadsBurst has to be positive", "rateLimitReadsBurst", config.RateLimitReadsBurst)
+	}
+	if config.RateLimitWritesPerSecond <= 0 {
+		fatal("rateLimitWritesPerSecond has to be positive", "rateLimitWritesPerSecond", config.RateLimitWritesPerSecond)
+	}
+	if config.RateLimitWritesBurst <= 0 {
+		fatal("rateLimitWritesBurst has to be positive", "rateLimitWritesBurst", config.RateLimitWritesBurst)
+	}
+	if config.RateLimitLoginsPerSecond <= 0 {
+		fatal("rateLimitLoginsPerSecond has to be positive", "rateLimitLoginsPerSecond", config.RateLimitLoginsPerSecond)
+	}
+	if config.RateLimitLoginsBurst <= 0 {
+		fatal("rateLimitLoginsBurst has to be positive", "rateLimitLoginsBurst", config.RateLimitLoginsBurst)
+	}
+	limits := map[string]ratelimit.Limit{
+		ratelimit.Reads:  {PerSecond: config.RateLimitReadsPerSecond, Burst: config.RateLimitReadsBurst},
+		ratelimit.Writes: {PerSecond: config.RateLimitWritesPerSecond, Burst: config.RateLimitWritesBurst},
+		ratelimit.Logins: {PerSecond: config.RateLimitLoginsPerSecond, Burst: config.RateLimitLoginsBurst},
+	}
+	return ratelimit.New(store, limits, identify)
+}
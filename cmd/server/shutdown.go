@@ -0,0 +1,37 @@
+package main
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// backgroundTasks keeps track of the tasks running until the background context is done
+type backgroundTasks struct {
+	sync.WaitGroup
+}
+
+func (b *backgroundTasks) Go(task func()) {
+	b.Add(1)
+	go func() {
+		defer b.Done()
+		task()
+	}()
+}
+
+// stopGracefully stops the server once the RPCs in flight are done, or abruptly once the timeout passed
+func stopGracefully(s *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		slog.Warn("RPCs still in flight after the shutdown timeout, stopping anyway", "timeout", timeout)
+		s.Stop()
+	}
+}
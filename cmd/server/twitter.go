@@ -1,29 +1,102 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/spf13/viper"
 	"github.com/twitter/auth"
+	"github.com/twitter/blobstore/local"
+	"github.com/twitter/bookmarks"
+	"github.com/twitter/discovery"
+	"github.com/twitter/hashtags"
+	"github.com/twitter/health"
+	"github.com/twitter/idempotency"
+	"github.com/twitter/impressions"
+	"github.com/twitter/lists"
+	"github.com/twitter/logging"
+	"github.com/twitter/media"
+	"github.com/twitter/metrics"
+	"github.com/twitter/models"
+	"github.com/twitter/polls"
 	"github.com/twitter/posts"
+	"github.com/twitter/ratelimit"
+	"github.com/twitter/search"
 	"github.com/twitter/storage/etcd"
+	"github.com/twitter/storage/instrumented"
 	"github.com/twitter/storage/memory"
+	"github.com/twitter/storage/traced"
+	"github.com/twitter/tlsconfig"
+	"github.com/twitter/tracing"
 	"github.com/twitter/twitter"
 	"github.com/twitter/users"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type Config struct {
-	Version            int      `map_structure:"version"`
-	GRPCPort           string   `map_structure:"grpcPort"`
-	EtcdEndpoints      []string `map_structure:"etcdEndpoints"`
-	SigningSecret      string   `map_structure:"signingSecret"`
-	MemoryType         string   `map_structure:"memoryType"`
-	TokenValidityHours int      `map_structure:"tokenValidityHours"`
-	Hostname           string   `map_structure:"hostName"`
+	Version                  int      `map_structure:"version"`
+	GRPCPort                 string   `map_structure:"grpcPort"`
+	MetricsPort              string   `map_structure:"metricsPort"`
+	EtcdEndpoints            []string `map_structure:"etcdEndpoints"`
+	SigningSecret            string   `map_structure:"signingSecret"`
+	MemoryType               string   `map_structure:"memoryType"`
+	TokenValidityHours       int      `map_structure:"tokenValidityHours"`
+	Hostname                 string   `map_structure:"hostName"`
+	BlobStoreType            string   `map_structure:"blobStoreType"`
+	MediaDirectory           string   `map_structure:"mediaDirectory"`
+	MaxUploadBytes           int64    `map_structure:"maxUploadBytes"`
+	EditWindowMinutes        int      `map_structure:"editWindowMinutes"`
+	PublishIntervalSeconds   int      `map_structure:"publishIntervalSeconds"`
+	FeedHalfLifeHours        int      `map_structure:"feedHalfLifeHours"`
+	FeedIncludeOwnPosts      bool     `map_structure:"feedIncludeOwnPosts"`
+	FeedIncludeReposts       bool     `map_structure:"feedIncludeReposts"`
+	FeedReplyPolicy          string   `map_structure:"feedReplyPolicy"`
+	ImpressionFlushSeconds   int      `map_structure:"impressionFlushSeconds"`
+	TracingExporter          string   `map_structure:"tracingExporter"`
+	TracingDestination       string   `map_structure:"tracingDestination"`
+	LogLevel                 string   `map_structure:"logLevel"`
+	LogFormat                string   `map_structure:"logFormat"`
+	HealthCheckSeconds       int      `map_structure:"healthCheckSeconds"`
+	ShutdownTimeoutSeconds   int      `map_structure:"shutdownTimeoutSeconds"`
+	TLSEnabled               bool     `map_structure:"tlsEnabled"`
+	TLSCertFile              string   `map_structure:"tlsCertFile"`
+	TLSKeyFile               string   `map_structure:"tlsKeyFile"`
+	TLSClientCAFile          string   `map_structure:"tlsClientCAFile"`
+	EtcdTLSEnabled           bool     `map_structure:"etcdTLSEnabled"`
+	EtcdTLSCAFile            string   `map_structure:"etcdTLSCAFile"`
+	EtcdTLSCertFile          string   `map_structure:"etcdTLSCertFile"`
+	EtcdTLSKeyFile           string   `map_structure:"etcdTLSKeyFile"`
+	AdvertiseAddress         string   `map_structure:"advertiseAddress"`
+	RegistrationTTLSeconds   int      `map_structure:"registrationTTLSeconds"`
+	RateLimitStore           string   `map_structure:"rateLimitStore"`
+	RateLimitReadsPerSecond  float64  `map_structure:"rateLimitReadsPerSecond"`
+	RateLimitReadsBurst      int      `map_structure:"rateLimitReadsBurst"`
+	RateLimitWritesPerSecond float64  `map_structure:"rateLimitWritesPerSecond"`
+	RateLimitWritesBurst     int      `map_structure:"rateLimitWritesBurst"`
+	RateLimitLoginsPerSecond float64  `map_structure:"rateLimitLoginsPerSecond"`
+	RateLimitLoginsBurst     int      `map_structure:"rateLimitLoginsBurst"`
+	RateLimitTrustedProxies  []string `map_structure:"rateLimitTrustedProxies"`
+	IdempotencyKeyTTLHours   int      `map_structure:"idempotencyKeyTTLHours"`
+}
+
+// fatal logs the error and exits without running the deferred calls
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
 
 func GetConfig(config *Config) error {
@@ -44,41 +117,237 @@ func main() {
 	err := GetConfig(config)
 
 	if err != nil {
-		log.Fatalf("Unable to load config file, exiting x_x \n%v\n", err)
-	} else {
-		log.Printf("Config loaded successfully, config file: %+v\n", viper.ConfigFileUsed())
+		fatal("Unable to load config file, exiting x_x", "err", err)
 	}
+	logger, err := logging.New(os.Stderr, config.LogLevel, config.LogFormat)
+	if err != nil {
+		fatal("Unable to set up logging", "err", err)
+	}
+	slog.SetDefault(logger)
+	slog.Info("Config loaded successfully", "file", viper.ConfigFileUsed())
 
-	log.Printf("Twitter version %d\n", config.Version)
+	slog.Info("Twitter", "version", config.Version)
 
 	listner, err := net.Listen("tcp", fmt.Sprintf("%s:%s", config.Hostname, config.GRPCPort))
-	log.Printf("Starting server at %s:%s\n", config.Hostname, config.GRPCPort)
+	slog.Info("Starting server", "host", config.Hostname, "port", config.GRPCPort)
 	if err != nil {
-		log.Fatalln(err)
+		fatal("Unable to listen", "err", err)
+	}
+	tracerProvider, err := tracing.New("twitter-server", tracing.Config{
+		Exporter:    config.TracingExporter,
+		Destination: config.TracingDestination,
+	})
+	if err != nil {
+		fatal("Unable to set up tracing", "err", err)
+	}
+	defer func() {
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			slog.Error("Unable to flush the remaining spans", "err", err)
+		}
+	}()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	grpcMetrics, err := metrics.NewGRPCMetrics(registry)
+	if err != nil {
+		fatal("Unable to register gRPC metrics", "err", err)
 	}
-	s := grpc.NewServer()
 	twtServer := &twitter.Server{}
 
+	var registrar *discovery.Registrar
+	// the client of the etcd storage, also used for the coordination between the servers, nil on the memory storage
+	var etcdClient *clientv3.Client
 	if config.MemoryType == "memory" {
 		twtServer.StorageService = memory.New()
+		if config.AdvertiseAddress != "" {
+			slog.Warn("Servers only share their storage on etcd, not registering", "advertiseAddress", config.AdvertiseAddress)
+		}
 	} else if config.MemoryType == "raft" {
-		storageService, err := etcd.New(config.EtcdEndpoints)
+		var etcdTLSConfig *tls.Config
+		if config.EtcdTLSEnabled {
+			etcdTLSConfig, err = tlsconfig.Client(tlsconfig.Config{
+				CertFile: config.EtcdTLSCertFile,
+				KeyFile:  config.EtcdTLSKeyFile,
+				CAFile:   config.EtcdTLSCAFile,
+			})
+			if err != nil {
+				fatal("Unable to load the etcd TLS certificates", "err", err)
+			}
+		}
+		// the storage closes the client once it is closed itself
+		etcdClient, err = etcd.NewClient(config.EtcdEndpoints, etcdTLSConfig)
 		if err != nil {
-			log.Println("Unable to connect to etcd")
-			log.Fatal(err)
+			fatal("Unable to connect to etcd", "err", err)
+		}
+		twtServer.StorageService = etcd.FromClient(etcdClient)
+		if config.AdvertiseAddress != "" {
+			if config.RegistrationTTLSeconds <= 0 {
+				fatal("registrationTTLSeconds has to be positive", "registrationTTLSeconds", config.RegistrationTTLSeconds)
+			}
+			registrar, err = discovery.NewRegistrar(etcdClient, config.AdvertiseAddress, time.Duration(config.RegistrationTTLSeconds)*time.Second)
+			if err != nil {
+				fatal("Unable to register in etcd", "err", err)
+			}
 		}
-		twtServer.StorageService = storageService
 	} else {
-		log.Fatalf("Unrecognized type of memory supplied: %s\n", config.MemoryType)
+		fatal("Unrecognized type of memory supplied", "memoryType", config.MemoryType)
+	}
+	twtServer.StorageService, err = instrumented.New(twtServer.StorageService, registry)
+	if err != nil {
+		fatal("Unable to register storage metrics", "err", err)
+	}
+	twtServer.StorageService = traced.New(twtServer.StorageService, tracerProvider)
+	if err := metrics.RegisterContentCounts(registry, twtServer.StorageService); err != nil {
+		fatal("Unable to register content metrics", "err", err)
+	}
+	metricsServer := serveMetrics(fmt.Sprintf("%s:%s", config.Hostname, config.MetricsPort), registry)
+
+	if config.BlobStoreType == "local" {
+		blobStore, err := local.New(config.MediaDirectory)
+		if err != nil {
+			fatal("Unable to create media directory", "err", err)
+		}
+		twtServer.MediaService = media.New(blobStore, config.MaxUploadBytes)
+	} else {
+		fatal("Unrecognized type of blob store supplied", "blobStoreType", config.BlobStoreType)
 	}
 
 	twtServer.AuthService = auth.New(time.Duration(config.TokenValidityHours)*time.Hour, config.SigningSecret)
-	twtServer.PostService = posts.New(twtServer.StorageService)
+	if config.FeedHalfLifeHours <= 0 {
+		fatal("feedHalfLifeHours has to be positive", "feedHalfLifeHours", config.FeedHalfLifeHours)
+	}
+	feedScorer := posts.NewDecayScorer(time.Duration(config.FeedHalfLifeHours) * time.Hour)
+	twtServer.PostService = posts.New(twtServer.StorageService, time.Duration(config.EditWindowMinutes)*time.Minute, feedScorer)
+	replyPolicy, exists := models.ReplyPolicy_value["REPLIES_"+strings.ToUpper(config.FeedReplyPolicy)]
+	if !exists {
+		fatal("Unrecognized feed reply policy supplied", "feedReplyPolicy", config.FeedReplyPolicy)
+	}
+	twtServer.DefaultFeedOptions = &models.FeedOptions{
+		IncludeOwnPosts: config.FeedIncludeOwnPosts,
+		IncludeReposts:  config.FeedIncludeReposts,
+		Replies:         models.ReplyPolicy(replyPolicy),
+	}
 	twtServer.UserService = users.New(twtServer.AuthService, twtServer.StorageService)
+	twtServer.HashtagService = hashtags.New(twtServer.StorageService)
+	twtServer.SearchService = search.New()
+	twtServer.BookmarkService = bookmarks.New(twtServer.StorageService)
+	twtServer.PollService = polls.New(twtServer.StorageService)
+	twtServer.ListService = lists.New(twtServer.StorageService, twtServer.PostService)
+	twtServer.ImpressionService = impressions.New(twtServer.StorageService)
 	defer twtServer.StorageService.Close()
+	unaryInterceptors := []grpc.UnaryServerInterceptor{otelgrpc.UnaryServerInterceptor(), logging.UnaryServerInterceptor(logger), grpcMetrics.UnaryServerInterceptor()}
+	streamInterceptors := []grpc.StreamServerInterceptor{otelgrpc.StreamServerInterceptor(), logging.StreamServerInterceptor(logger), grpcMetrics.StreamServerInterceptor()}
+	// the requests are limited and replayed per user, or per client address for the requests made without logging in
+	identify := ratelimit.UserOrIP(func(token string) (string, error) {
+		user, err := twtServer.AuthService.VerifyToken(token)
+		if err != nil {
+			return "", err
+		}
+		return user.UserName, nil
+	}, config.RateLimitTrustedProxies)
+	// the refused RPCs are still logged and counted
+	if limiter := newRateLimiter(config, etcdClient, identify); limiter != nil {
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor())
+	}
+	if config.IdempotencyKeyTTLHours <= 0 {
+		fatal("idempotencyKeyTTLHours has to be positive", "idempotencyKeyTTLHours", config.IdempotencyKeyTTLHours)
+	}
+	idempotencyKeyTTL := time.Duration(config.IdempotencyKeyTTLHours) * time.Hour
+	idempotencyStore := idempotency.NewMemoryStore(idempotencyKeyTTL)
+	if etcdClient != nil {
+		// a request repeated on another server is replayed too
+		idempotencyStore = idempotency.NewEtcdStore(etcdClient, idempotencyKeyTTL)
+	}
+	unaryInterceptors = append(unaryInterceptors, idempotency.New(idempotencyStore, idempotency.Identify(identify)).UnaryServerInterceptor())
+	serverOptions := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+	if config.TLSEnabled {
+		// clients have to present a certificate signed by the client CA when one is configured
+		tlsConfig, err := tlsconfig.Server(tlsconfig.Config{
+			CertFile: config.TLSCertFile,
+			KeyFile:  config.TLSKeyFile,
+			CAFile:   config.TLSClientCAFile,
+		})
+		if err != nil {
+			fatal("Unable to load the TLS certificates", "err", err)
+		}
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	s := grpc.NewServer(serverOptions...)
 	twitter.RegisterTwitterServer(s, twtServer)
+	if config.HealthCheckSeconds <= 0 {
+		fatal("healthCheckSeconds has to be positive", "healthCheckSeconds", config.HealthCheckSeconds)
+	}
+	healthCheckInterval := time.Duration(config.HealthCheckSeconds) * time.Second
+	healthMonitor := health.NewMonitor(twtServer.StorageService, healthCheckInterval, twitter.Twitter_ServiceDesc.ServiceName)
+	healthpb.RegisterHealthServer(s, healthMonitor.Server())
+
+	if config.PublishIntervalSeconds <= 0 {
+		fatal("publishIntervalSeconds has to be positive", "publishIntervalSeconds", config.PublishIntervalSeconds)
+	}
+	if config.ImpressionFlushSeconds <= 0 {
+		fatal("impressionFlushSeconds has to be positive", "impressionFlushSeconds", config.ImpressionFlushSeconds)
+	}
+	if config.ShutdownTimeoutSeconds <= 0 {
+		fatal("shutdownTimeoutSeconds has to be positive", "shutdownTimeoutSeconds", config.ShutdownTimeoutSeconds)
+	}
+	shutdownTimeout := time.Duration(config.ShutdownTimeoutSeconds) * time.Second
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	// the index only gets the posts and users from the storage, so that it has the ones added through the other
+	// servers too, including the scheduled posts the leader publishes
+	syncSearchIndex, err := search.Sync(backgroundCtx, twtServer.SearchService, twtServer.StorageService)
+	if err != nil {
+		fatal("Unable to build the search index", "err", err)
+	}
+	var background backgroundTasks
+	background.Go(syncSearchIndex)
+	background.Go(func() {
+		runPublisher(backgroundCtx, twtServer, time.Duration(config.PublishIntervalSeconds)*time.Second)
+	})
+	background.Go(func() { twtServer.RemoveExpiredPosts(backgroundCtx) })
+	background.Go(func() {
+		runImpressionFlusher(backgroundCtx, twtServer, time.Duration(config.ImpressionFlushSeconds)*time.Second)
+	})
+	background.Go(func() { healthMonitor.Run(backgroundCtx, healthCheckInterval) })
+
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stopSignals()
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(listner)
+	}()
+	registrationCtx, stopRegistration := context.WithCancel(context.Background())
+	defer stopRegistration()
+	deregistered := make(chan struct{})
+	go func() {
+		defer close(deregistered)
+		if registrar != nil {
+			registrar.Run(registrationCtx)
+		}
+	}()
+	select {
+	case err := <-served:
+		slog.Error("Unable to serve", "err", err)
+	case <-signalCtx.Done():
+	}
 
-	if err := s.Serve(listner); err != nil {
-		log.Fatalln(err)
+	// new RPCs are refused while the ones in flight finish, then the background tasks stop, flushing the
+	// impressions they hold, before the deferred calls close the storage and flush the traces
+	slog.Info("Shutting down, no longer serving")
+	// the web tier stops picking the server before it stops serving the RPCs in flight
+	stopRegistration()
+	<-deregistered
+	healthMonitor.Shutdown()
+	stopGracefully(s, shutdownTimeout)
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Unable to stop serving metrics", "err", err)
 	}
+	stopBackground()
+	background.Wait()
+	slog.Info("Shut down")
 }
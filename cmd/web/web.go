@@ -2,27 +2,67 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/spf13/viper"
+	"github.com/twitter/discovery"
+	"github.com/twitter/gateway"
+	"github.com/twitter/logging"
 	"github.com/twitter/models"
+	"github.com/twitter/ratelimit"
+	"github.com/twitter/tlsconfig"
+	"github.com/twitter/tracing"
 	"github.com/twitter/twitter"
 	"github.com/twitter/web"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/resolver"
 )
 
 type Config struct {
-	Version         int    `map_structure:"version"`
-	ServiceGRPCPort string `map_structure:"serviceGrpcPort"`
-	ServiceHostname string `map_structure:"serviceHostname"`
-	Hostname        string `map_structure:"hostName"`
-	HTTPPort        string `map_structure:"httpPort"`
-	ServiceName     string `map_structure:"serviceName"`
+	Version                int      `map_structure:"version"`
+	ServiceGRPCPort        string   `map_structure:"serviceGrpcPort"`
+	ServiceHostname        string   `map_structure:"serviceHostname"`
+	Hostname               string   `map_structure:"hostName"`
+	HTTPPort               string   `map_structure:"httpPort"`
+	ServiceName            string   `map_structure:"serviceName"`
+	TracingExporter        string   `map_structure:"tracingExporter"`
+	TracingDestination     string   `map_structure:"tracingDestination"`
+	LogLevel               string   `map_structure:"logLevel"`
+	LogFormat              string   `map_structure:"logFormat"`
+	ShutdownTimeoutSeconds int      `map_structure:"shutdownTimeoutSeconds"`
+	TLSEnabled             bool     `map_structure:"tlsEnabled"`
+	TLSCAFile              string   `map_structure:"tlsCAFile"`
+	TLSCertFile            string   `map_structure:"tlsCertFile"`
+	TLSKeyFile             string   `map_structure:"tlsKeyFile"`
+	TLSServerName          string   `map_structure:"tlsServerName"`
+	ServiceDiscovery       string   `map_structure:"serviceDiscovery"`
+	ServiceAddresses       []string `map_structure:"serviceAddresses"`
+	RetryAttempts          int      `map_structure:"retryAttempts"`
+	EtcdEndpoints          []string `map_structure:"etcdEndpoints"`
+	EtcdTLSEnabled         bool     `map_structure:"etcdTLSEnabled"`
+	EtcdTLSCAFile          string   `map_structure:"etcdTLSCAFile"`
+	EtcdTLSCertFile        string   `map_structure:"etcdTLSCertFile"`
+	EtcdTLSKeyFile         string   `map_structure:"etcdTLSKeyFile"`
+}
+
+// fatal logs the error and exits without running the deferred calls
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
 
 func GetConfig(config *Config) error {
@@ -38,21 +78,9 @@ func GetConfig(config *Config) error {
 	return err
 }
 
-func Cleanup(serviceConnection *grpc.ClientConn) {
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, syscall.SIGTERM, syscall.SIGINT)
-	for {
-		s := <-c
-		if s == syscall.SIGTERM || s == syscall.SIGINT {
-			log.Printf("Gracefully shutting down the server, closing connections...\n")
-			err := serviceConnection.Close()
-			if err != nil {
-				log.Printf("Error while closing the client connection: %v\n", err)
-			}
-			os.Exit(1)
-		}
-	}
-
+// handle registers the handler for the pattern with a span, named after the pattern, around every request
+func handle(pattern string, handler http.HandlerFunc) {
+	http.Handle(pattern, otelhttp.NewHandler(handler, pattern))
 }
 
 func main() {
@@ -60,58 +88,186 @@ func main() {
 	err := GetConfig(config)
 
 	if err != nil {
-		log.Fatalf("Unable to load config file, exiting x_x \n%v\n", err)
-	} else {
-		log.Printf("Config loaded successfully, config file: %+v\n", viper.ConfigFileUsed())
+		fatal("Unable to load config file, exiting x_x", "err", err)
+	}
+	logger, err := logging.New(os.Stderr, config.LogLevel, config.LogFormat)
+	if err != nil {
+		fatal("Unable to set up logging", "err", err)
 	}
+	slog.SetDefault(logger)
+	slog.Info("Config loaded successfully", "file", viper.ConfigFileUsed())
 
-	log.Printf("%s Client version %d\n", config.ServiceName, config.Version)
+	slog.Info(config.ServiceName+" Client", "version", config.Version)
 
+	tracerProvider, err := tracing.New("twitter-web", tracing.Config{
+		Exporter:    config.TracingExporter,
+		Destination: config.TracingDestination,
+	})
+	if err != nil {
+		fatal("Unable to set up tracing", "err", err)
+	}
+	defer func() {
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			slog.Error("Unable to flush the remaining spans", "err", err)
+		}
+	}()
+
+	transportCredentials := insecure.NewCredentials()
+	if config.TLSEnabled {
+		// the certificate is only needed when the service requires client certificates
+		tlsConfig, err := tlsconfig.Client(tlsconfig.Config{
+			CertFile:   config.TLSCertFile,
+			KeyFile:    config.TLSKeyFile,
+			CAFile:     config.TLSCAFile,
+			ServerName: config.TLSServerName,
+		})
+		if err != nil {
+			fatal("Unable to load the TLS certificates", "err", err)
+		}
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+	var serviceResolver resolver.Builder
+	var serviceTarget string
+	if config.ServiceDiscovery == "static" {
+		addresses := config.ServiceAddresses
+		if len(addresses) == 0 {
+			addresses = []string{fmt.Sprintf("%s:%s", config.ServiceHostname, config.ServiceGRPCPort)}
+		}
+		serviceResolver, serviceTarget = discovery.Static(addresses)
+	} else if config.ServiceDiscovery == "etcd" {
+		// the servers found in etcd are verified against a single name rather than their own host
+		if config.TLSEnabled && config.TLSServerName == "" {
+			fatal("tlsServerName is required to verify the servers found through etcd")
+		}
+		var etcdTLSConfig *tls.Config
+		if config.EtcdTLSEnabled {
+			etcdTLSConfig, err = tlsconfig.Client(tlsconfig.Config{
+				CertFile: config.EtcdTLSCertFile,
+				KeyFile:  config.EtcdTLSKeyFile,
+				CAFile:   config.EtcdTLSCAFile,
+			})
+			if err != nil {
+				fatal("Unable to load the etcd TLS certificates", "err", err)
+			}
+		}
+		etcdClient, err := clientv3.New(clientv3.Config{
+			Endpoints:   config.EtcdEndpoints,
+			DialTimeout: 5 * time.Second,
+			TLS:         etcdTLSConfig,
+		})
+		if err != nil {
+			fatal("Unable to connect to etcd", "err", err)
+		}
+		defer etcdClient.Close()
+		serviceResolver, serviceTarget, err = discovery.Etcd(etcdClient)
+		if err != nil {
+			fatal("Unable to watch the servers registered in etcd", "err", err)
+		}
+	} else {
+		fatal("Unrecognized service discovery supplied", "serviceDiscovery", config.ServiceDiscovery)
+	}
+	if config.RetryAttempts <= 0 {
+		fatal("retryAttempts has to be positive", "retryAttempts", config.RetryAttempts)
+	}
 	twitterConn, err := grpc.Dial(
-		fmt.Sprintf("%s:%s", config.ServiceHostname, config.ServiceGRPCPort),
-		grpc.WithInsecure(),
+		serviceTarget,
+		grpc.WithResolvers(serviceResolver),
+		grpc.WithDefaultServiceConfig(discovery.ServiceConfig(twitter.Twitter_ServiceDesc.ServiceName, config.RetryAttempts)),
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor()),
 	)
 
 	if err != nil {
-		log.Fatalf("Could not connect: %v\n", err)
+		fatal("Could not connect", "err", err)
 	}
 
 	defer func() {
-		log.Printf("Gracefully shutting down the server, closing connections...\n")
+		slog.Info("Closing the connection to " + config.ServiceName)
 		err := twitterConn.Close()
 		if err != nil {
-			log.Printf("Error while closing the client connection: %v\n", err)
+			slog.Error("Error while closing the client connection", "err", err)
 		}
 	}()
-	go Cleanup(twitterConn)
 
 	twitterClient := twitter.NewTwitterClient(twitterConn)
 
 	if _, err := twitterClient.HealthCheck(context.Background(), &models.Empty{}); err != nil {
-		log.Fatalf("%s service not running", config.ServiceName)
+		fatal(config.ServiceName+" service not running", "err", err)
 	}
 
 	webService := &web.WebService{
 		TwitterService: twitterClient,
+		HealthService:  healthpb.NewHealthClient(twitterConn),
 	}
 
-	http.HandleFunc("/", webService.Index)
-	http.HandleFunc("/login", webService.Login)
-	http.HandleFunc("/register", webService.Register)
-	http.HandleFunc("/home", webService.Home)
-	http.HandleFunc("/createPost", webService.CreatePost)
-	http.HandleFunc("/followUser", webService.FollowUser)
-	http.HandleFunc("/unFollowUser", webService.DeleteFollowing)
-	http.HandleFunc("/profile", webService.Profile)
-	http.HandleFunc("/otherUser", webService.OtherUser)
-	http.HandleFunc("/deletePost", webService.DeletePost)
-	http.HandleFunc("/logout", webService.Logout)
-	err = http.ListenAndServe(
-		fmt.Sprintf("%s:%s", config.Hostname, config.HTTPPort),
-		nil,
-	)
-	if err != nil {
-		log.Fatal("ListenAndServe: ", err)
+	// probes are left out of the traces
+	http.HandleFunc("/healthz", webService.Healthz)
+	http.HandleFunc("/readyz", webService.Readyz)
+
+	handle("/", webService.Index)
+	handle("/login", webService.Login)
+	handle("/register", webService.Register)
+	handle("/home", webService.Home)
+	handle("/createPost", webService.CreatePost)
+	handle("/followUser", webService.FollowUser)
+	handle("/unFollowUser", webService.DeleteFollowing)
+	handle("/blockUser", webService.BlockUser)
+	handle("/unBlockUser", webService.UnBlockUser)
+	handle("/profile", webService.Profile)
+	handle("/otherUser", webService.OtherUser)
+	handle("/deletePost", webService.DeletePost)
+	handle("/editPost", webService.EditPost)
+	handle("/repost", webService.Repost)
+	handle("/unRepost", webService.UnRepost)
+	handle("/logout", webService.Logout)
+	handle("/search", webService.Search)
+	handle("/media/", webService.Media)
+	handle("/bookmarks", webService.Bookmarks)
+	handle("/bookmarkPost", webService.BookmarkPost)
+	handle("/removeBookmark", webService.RemoveBookmark)
+	handle("/pinPost", webService.PinPost)
+	handle("/unpinPost", webService.UnpinPost)
+	handle("/votePoll", webService.VotePoll)
+	handle("/lists", webService.Lists)
+	handle("/list", webService.List)
+	handle("/createList", webService.CreateList)
+	handle("/updateList", webService.UpdateList)
+	handle("/deleteList", webService.DeleteList)
+	handle("/addListMember", webService.AddListMember)
+	handle("/removeListMember", webService.RemoveListMember)
+	// the JSON API for the clients that don't speak gRPC, calling the service over the same connection
+	handle(gateway.Prefix+"/", gateway.New(twitterConn).ServeHTTP)
+	if config.ShutdownTimeoutSeconds <= 0 {
+		fatal("shutdownTimeoutSeconds has to be positive", "shutdownTimeoutSeconds", config.ShutdownTimeoutSeconds)
+	}
+	server := &http.Server{
+		Addr:    fmt.Sprintf("%s:%s", config.Hostname, config.HTTPPort),
+		Handler: logging.Handler(logger, ratelimit.ForwardClientIP(http.DefaultServeMux)),
+	}
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stopSignals()
+	served := make(chan error, 1)
+	slog.Info("Starting server", "host", config.Hostname, "port", config.HTTPPort)
+	go func() {
+		served <- server.ListenAndServe()
+	}()
+	select {
+	case err := <-served:
+		if !errors.Is(err, http.ErrServerClosed) {
+			fatal("ListenAndServe failed", "err", err)
+		}
+	case <-signalCtx.Done():
+	}
+
+	// new requests are refused while the ones in flight finish, then the deferred calls close the connection to
+	// the service and flush the traces
+	slog.Info("Shutting down")
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), time.Duration(config.ShutdownTimeoutSeconds)*time.Second)
+	defer cancelShutdown()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("Requests still in flight after the shutdown timeout, closing anyway", "err", err)
+		server.Close()
 	}
-	log.Printf("Starting server at %s:%s\n", config.Hostname, config.HTTPPort)
+	slog.Info("Shut down")
 }
@@ -0,0 +1,115 @@
+// Package discovery lets the web tier find the twitter servers, from a static list or from the registrations the
+// servers keep in etcd, and spreads the RPCs over the servers that report themselves healthy
+package discovery
+
+import (
+	"encoding/json"
+	"net"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	etcdresolver "go.etcd.io/etcd/client/v3/naming/resolver"
+	// the client side health checks of the service config need the health package registered
+	_ "google.golang.org/grpc/health"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+const (
+	// Prefix is the etcd key prefix the servers register their address under
+	Prefix       = "twitter-servers"
+	staticScheme = "static"
+)
+
+// idempotentMethods are retried on another server when the server they were sent to is unavailable. They either
+// only read or set a state that doesn't change when it's set again, the servers don't count the impressions of the
+// posts read by a retry again.
+var idempotentMethods = []string{
+	"HealthCheck",
+	"LoginUser",
+	"GetFeed",
+	"GetUser",
+	"GetUserProfile",
+	"GetSelf",
+	"GetMyPosts",
+	"GetPost",
+	"GetHashtagTimeline",
+	"GetTrending",
+	"Search",
+	"GetMedia",
+	"GetPostHistory",
+	"ListBookmarks",
+	"GetList",
+	"GetUserLists",
+	"GetListTimeline",
+	"SuggestUsers",
+	"GetPostStats",
+	"FollowUser",
+	"UnFollowUser",
+	"BlockUser",
+	"UnBlockUser",
+	"BookmarkPost",
+	"RemoveBookmark",
+	"PinPost",
+	"UnpinPost",
+	"AddListMember",
+	"RemoveListMember",
+}
+
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method"`
+}
+
+// ServiceConfig returns the gRPC service config balancing the RPCs round robin over the servers whose health service
+// reports the service as serving, and making up to attempts attempts of the idempotent RPCs
+func ServiceConfig(service string, attempts int) string {
+	config := map[string]any{
+		"loadBalancingConfig": []any{map[string]any{"round_robin": map[string]any{}}},
+		"healthCheckConfig":   map[string]any{"serviceName": service},
+	}
+	// gRPC refuses retry policies of a single attempt
+	if attempts > 1 {
+		var names []methodName
+		for _, method := range idempotentMethods {
+			names = append(names, methodName{Service: service, Method: method})
+		}
+		config["methodConfig"] = []any{map[string]any{
+			"name": names,
+			"retryPolicy": map[string]any{
+				"maxAttempts":          attempts,
+				"initialBackoff":       "0.1s",
+				"maxBackoff":           "1s",
+				"backoffMultiplier":    2,
+				"retryableStatusCodes": []string{"UNAVAILABLE"},
+			},
+		}}
+	}
+	serviceConfig, _ := json.Marshal(config)
+	return string(serviceConfig)
+}
+
+// Static returns the resolver of the servers at the addresses, in host:port form, and the target to dial with it
+func Static(addresses []string) (resolver.Builder, string) {
+	staticResolver := manual.NewBuilderWithScheme(staticScheme)
+	var state resolver.State
+	for _, address := range addresses {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		// the certificate of each server is verified against its own host rather than the target
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: address, ServerName: host})
+	}
+	staticResolver.InitialState(state)
+	return staticResolver, staticScheme + ":///" + Prefix
+}
+
+// Etcd returns the resolver of the servers registered in etcd, kept up to date as servers come and go, and the target
+// to dial with it
+func Etcd(client *clientv3.Client) (resolver.Builder, string, error) {
+	etcdResolver, err := etcdresolver.NewBuilder(client)
+	if err != nil {
+		return nil, "", err
+	}
+	return etcdResolver, "etcd:///" + Prefix, nil
+}
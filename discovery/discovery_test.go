@@ -0,0 +1,135 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/twitter"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServer counts the RPCs it serves, failing them as unavailable when it's broken
+type fakeServer struct {
+	twitter.UnimplementedTwitterServer
+	broken bool
+	served atomic.Int32
+}
+
+func (f *fakeServer) GetPost(ctx context.Context, post *models.Post) (*models.Post, error) {
+	f.served.Add(1)
+	if f.broken {
+		return nil, status.Error(codes.Unavailable, "broken")
+	}
+	return post, nil
+}
+
+func (f *fakeServer) CreatePost(ctx context.Context, post *models.Post) (*models.Post, error) {
+	f.served.Add(1)
+	if f.broken {
+		return nil, status.Error(codes.Unavailable, "broken")
+	}
+	return post, nil
+}
+
+// serve starts a server reporting the twitter service with the status, returning its address
+func serve(t *testing.T, fake *fakeServer, serving healthpb.HealthCheckResponse_ServingStatus) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error in listening: %+v\n", err)
+	}
+	server := grpc.NewServer()
+	twitter.RegisterTwitterServer(server, fake)
+	healthServer := grpchealth.NewServer()
+	healthServer.SetServingStatus(twitter.Twitter_ServiceDesc.ServiceName, serving)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestIdempotentMethods(t *testing.T) {
+	methods := map[string]bool{}
+	for _, method := range twitter.Twitter_ServiceDesc.Methods {
+		methods[method.MethodName] = true
+	}
+	for _, stream := range twitter.Twitter_ServiceDesc.Streams {
+		methods[stream.StreamName] = true
+	}
+	for _, method := range idempotentMethods {
+		if !methods[method] {
+			t.Errorf("No such method: %+v\n", method)
+		}
+	}
+
+	var config map[string]any
+	if err := json.Unmarshal([]byte(ServiceConfig("twitter.Twitter", 1)), &config); err != nil {
+		t.Fatalf("Error in decoding the service config: %+v\n", err)
+	}
+	if _, ok := config["methodConfig"]; ok {
+		t.Errorf("Retry policy of a single attempt: %+v\n", config)
+	}
+}
+
+func TestStatic(t *testing.T) {
+	healthy := &fakeServer{}
+	broken := &fakeServer{broken: true}
+	unhealthy := &fakeServer{}
+	addresses := []string{
+		serve(t, healthy, healthpb.HealthCheckResponse_SERVING),
+		serve(t, broken, healthpb.HealthCheckResponse_SERVING),
+		serve(t, unhealthy, healthpb.HealthCheckResponse_NOT_SERVING),
+	}
+
+	staticResolver, target := Static(addresses)
+	conn, err := grpc.Dial(target,
+		grpc.WithResolvers(staticResolver),
+		grpc.WithDefaultServiceConfig(ServiceConfig(twitter.Twitter_ServiceDesc.ServiceName, 3)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Error in dialing: %+v\n", err)
+	}
+	defer conn.Close()
+	client := twitter.NewTwitterClient(conn)
+
+	// the balancer only picks the servers once their health is known
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for broken.served.Load() == 0 {
+		if _, err := client.CreatePost(ctx, &models.Post{}, grpc.WaitForReady(true)); err != nil && ctx.Err() != nil {
+			t.Fatalf("Broken server never picked: %+v\n", err)
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		if _, err := client.GetPost(ctx, &models.Post{PostID: "1"}); err != nil {
+			t.Errorf("Idempotent RPC not retried on another server: %+v\n", err)
+		}
+	}
+	if healthy.served.Load() < 10 || broken.served.Load() < 2 {
+		t.Errorf("RPCs not spread over the servers: %+v %+v\n", healthy.served.Load(), broken.served.Load())
+	}
+
+	failed := 0
+	for i := 0; i < 10; i++ {
+		if _, err := client.CreatePost(ctx, &models.Post{}); status.Code(err) == codes.Unavailable {
+			failed++
+		}
+	}
+	if failed == 0 {
+		t.Error("RPC that isn't idempotent retried")
+	}
+	if unhealthy.served.Load() != 0 {
+		t.Errorf("RPCs sent to a server that isn't serving: %+v\n", unhealthy.served.Load())
+	}
+}
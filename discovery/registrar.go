@@ -0,0 +1,76 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.etcd.io/etcd/client/v3/naming/endpoints"
+)
+
+var ErrRegistrationLost = errors.New("The lease of the registration expired")
+
+// Registrar keeps the address of a server registered in etcd for as long as it runs
+type Registrar struct {
+	client  *clientv3.Client
+	manager endpoints.Manager
+	address string
+	ttl     time.Duration
+}
+
+// NewRegistrar creates the registrar of the server at the address. The registration expires ttl after the server
+// stopped renewing it, such as when it crashed.
+func NewRegistrar(client *clientv3.Client, address string, ttl time.Duration) (*Registrar, error) {
+	manager, err := endpoints.NewManager(client, Prefix)
+	if err != nil {
+		return nil, err
+	}
+	return &Registrar{
+		client:  client,
+		manager: manager,
+		address: address,
+		ttl:     ttl,
+	}, nil
+}
+
+// Run registers the server, and registers it again whenever the registration is lost, until ctx is done and the
+// registration is removed
+func (r *Registrar) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		err := r.register(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Error("Unable to stay registered in etcd", "address", r.address, "err", err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(r.ttl / 2):
+		}
+	}
+}
+
+// register keeps the address registered under the lease of a session until ctx is done or the session is lost
+func (r *Registrar) register(ctx context.Context) error {
+	// the session isn't bound to ctx, it still has to revoke its lease once ctx is done
+	session, err := concurrency.NewSession(r.client, concurrency.WithTTL(int(r.ttl.Seconds())))
+	if err != nil {
+		return err
+	}
+	// revoking the lease removes the registration right away rather than once it expires
+	defer session.Close()
+	key := Prefix + "/" + r.address
+	if err := r.manager.AddEndpoint(ctx, key, endpoints.Endpoint{Addr: r.address}, clientv3.WithLease(session.Lease())); err != nil {
+		return err
+	}
+	slog.Info("Registered in etcd", "address", r.address)
+	select {
+	case <-ctx.Done():
+		slog.Info("Deregistering from etcd", "address", r.address)
+		return nil
+	case <-session.Done():
+		return ErrRegistrationLost
+	}
+}
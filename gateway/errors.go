@@ -0,0 +1,47 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpStatuses maps the codes of the service's errors to the closest HTTP status
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// apiError is the body of the responses to failed requests
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeError writes the error as JSON with the HTTP status of its code, errors that aren't a status are internal
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	httpStatus, ok := httpStatuses[st.Code()]
+	if !ok {
+		httpStatus = http.StatusInternalServerError
+	}
+	body, _ := json.Marshal(apiError{Code: st.Code().String(), Message: st.Message()})
+	writeBody(w, httpStatus, body)
+}
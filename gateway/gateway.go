@@ -0,0 +1,316 @@
+// Package gateway serves the Twitter service as a JSON HTTP API, every RPC is mapped to a resource-style endpoint
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/twitter/idempotency"
+	"github.com/twitter/logging"
+	"github.com/twitter/media"
+	"github.com/twitter/models"
+	"github.com/twitter/twitter"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// Prefix is the path the API is served under
+	Prefix = "/api/v1"
+	// IdempotencyKeyHeader is the header of the key that makes repeating a write request safe
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// maxBodyBytes is the largest JSON request body accepted, media uploads are limited by the service instead
+	maxBodyBytes = 1 << 20
+)
+
+// route maps an RPC to an endpoint. The fields of the request are taken from the wildcards of the path, named after
+// the fields, and from the JSON body of POST and PATCH requests or the query string of the others. Ids containing a
+// slash, like the post ids of etcd, are escaped in the path.
+type route struct {
+	method string
+	path   string
+	rpc    string
+	// status of a successful response
+	status int
+}
+
+var routes = []route{
+	{"GET", "/health", "HealthCheck", http.StatusOK},
+	{"POST", "/users", "RegisterUser", http.StatusCreated},
+	{"GET", "/users/{UserName}", "GetUser", http.StatusOK},
+	{"GET", "/users/{UserName}/profile", "GetUserProfile", http.StatusOK},
+	{"GET", "/users/{UserName}/lists", "GetUserLists", http.StatusOK},
+	{"PUT", "/users/{UserName}/follow", "FollowUser", http.StatusOK},
+	{"DELETE", "/users/{UserName}/follow", "UnFollowUser", http.StatusOK},
+	{"PUT", "/users/{UserName}/block", "BlockUser", http.StatusOK},
+	{"DELETE", "/users/{UserName}/block", "UnBlockUser", http.StatusOK},
+	{"POST", "/sessions", "LoginUser", http.StatusCreated},
+	{"GET", "/me", "GetSelf", http.StatusOK},
+	{"GET", "/me/posts", "GetMyPosts", http.StatusOK},
+	{"GET", "/me/feed", "GetFeed", http.StatusOK},
+	{"GET", "/me/bookmarks", "ListBookmarks", http.StatusOK},
+	{"GET", "/me/suggestions", "SuggestUsers", http.StatusOK},
+	{"DELETE", "/me/pin", "UnpinPost", http.StatusOK},
+	{"POST", "/posts", "CreatePost", http.StatusCreated},
+	{"GET", "/posts/stats", "GetPostStats", http.StatusOK},
+	{"GET", "/posts/{PostID}", "GetPost", http.StatusOK},
+	{"PATCH", "/posts/{PostID}", "EditPost", http.StatusOK},
+	{"DELETE", "/posts/{PostID}", "DeletePost", http.StatusOK},
+	{"GET", "/posts/{PostID}/history", "GetPostHistory", http.StatusOK},
+	{"PUT", "/posts/{PostID}/bookmark", "BookmarkPost", http.StatusOK},
+	{"DELETE", "/posts/{PostID}/bookmark", "RemoveBookmark", http.StatusOK},
+	{"PUT", "/posts/{PostID}/pin", "PinPost", http.StatusOK},
+	{"POST", "/posts/{PostID}/votes", "VotePoll", http.StatusCreated},
+	{"POST", "/posts/{PostID}/reposts", "Repost", http.StatusCreated},
+	{"DELETE", "/posts/{PostID}/reposts", "UnRepost", http.StatusOK},
+	{"POST", "/scheduled-posts", "SchedulePost", http.StatusCreated},
+	{"GET", "/hashtags/{Tag}/posts", "GetHashtagTimeline", http.StatusOK},
+	{"GET", "/trending", "GetTrending", http.StatusOK},
+	{"GET", "/search", "Search", http.StatusOK},
+	{"POST", "/lists", "CreateList", http.StatusCreated},
+	{"GET", "/lists/{ListID}", "GetList", http.StatusOK},
+	{"PATCH", "/lists/{ListID}", "UpdateList", http.StatusOK},
+	{"DELETE", "/lists/{ListID}", "DeleteList", http.StatusOK},
+	{"GET", "/lists/{ListID}/posts", "GetListTimeline", http.StatusOK},
+	{"PUT", "/lists/{ListID}/members/{UserName}", "AddListMember", http.StatusOK},
+	{"DELETE", "/lists/{ListID}/members/{UserName}", "RemoveListMember", http.StatusOK},
+	// the media is the raw body of the requests and responses rather than JSON
+	{"POST", "/media", "UploadMedia", http.StatusCreated},
+	{"GET", "/media/{MediaID}", "GetMedia", http.StatusOK},
+}
+
+// wildcards returns the names of the wildcards of the path
+func (rt route) wildcards() []string {
+	var names []string
+	for _, segment := range strings.Split(rt.path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, strings.Trim(segment, "{}"))
+		}
+	}
+	return names
+}
+
+// hasBody reports whether the request fields are sent as a JSON body rather than in the query string
+func (rt route) hasBody() bool {
+	return rt.method == "POST" || rt.method == "PATCH"
+}
+
+// Gateway translates the requests to the API into calls to the Twitter service
+type Gateway struct {
+	conn    grpc.ClientConnInterface
+	client  twitter.TwitterClient
+	mux     *http.ServeMux
+	openAPI []byte
+}
+
+// New creates the gateway calling the Twitter service over the connection
+func New(conn grpc.ClientConnInterface) *Gateway {
+	g := &Gateway{
+		conn:   conn,
+		client: twitter.NewTwitterClient(conn),
+		mux:    http.NewServeMux(),
+	}
+	methods := service().Methods()
+	for _, rt := range routes {
+		method := methods.ByName(protoreflect.Name(rt.rpc))
+		var handler http.HandlerFunc
+		switch rt.rpc {
+		case "UploadMedia":
+			handler = g.uploadMedia(rt)
+		case "GetMedia":
+			handler = g.getMedia
+		default:
+			handler = g.unary(rt, method)
+		}
+		g.mux.HandleFunc(rt.method+" "+Prefix+rt.path, handler)
+	}
+	document, err := json.Marshal(openAPI(routes))
+	if err != nil {
+		panic(err)
+	}
+	g.openAPI = document
+	g.mux.HandleFunc("GET "+Prefix+"/openapi.json", g.serveOpenAPI)
+	g.mux.HandleFunc(Prefix+"/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, status.Errorf(codes.NotFound, "No endpoint %s %s", r.Method, r.URL.Path))
+	})
+	return g
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+func (g *Gateway) serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(g.openAPI)
+}
+
+// service returns the descriptor of the Twitter service
+func service() protoreflect.ServiceDescriptor {
+	return twitter.File_twitter_proto.Services().ByName("Twitter")
+}
+
+// newMessage creates an empty message of the type described
+func newMessage(desc protoreflect.MessageDescriptor) proto.Message {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(desc.FullName())
+	if err != nil {
+		panic(err)
+	}
+	return messageType.New().Interface()
+}
+
+// withToken passes the bearer token of the request on to the service the way the web frontend does with its cookie
+func withToken(r *http.Request) (context.Context, error) {
+	authorization := r.Header.Get("Authorization")
+	if authorization == "" {
+		return r.Context(), nil
+	}
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "Authorization is not a bearer token")
+	}
+	return metadata.AppendToOutgoingContext(r.Context(), "token", token), nil
+}
+
+// withIdempotencyKey passes the Idempotency-Key header on to the service, which replays the response of the first
+// request with the key to the repeated ones
+func withIdempotencyKey(ctx context.Context, r *http.Request) context.Context {
+	key := r.Header.Get(IdempotencyKeyHeader)
+	if key == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, idempotency.Key, key)
+}
+
+func (g *Gateway) unary(rt route, method protoreflect.MethodDescriptor) http.HandlerFunc {
+	fullMethod := "/" + string(service().FullName()) + "/" + string(method.Name())
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := withToken(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		ctx = withIdempotencyKey(ctx, r)
+		req := newMessage(method.Input())
+		if err := decodeRequest(w, r, rt, req); err != nil {
+			writeError(w, err)
+			return
+		}
+		resp := newMessage(method.Output())
+		var header metadata.MD
+		if err := g.conn.Invoke(ctx, fullMethod, req, resp, grpc.Header(&header)); err != nil {
+			writeError(w, err)
+			return
+		}
+		if rt.rpc == "LoginUser" {
+			// the token is sent back in the header rather than the user
+			body, _ := json.Marshal(map[string]string{"token": firstOf(header.Get("token"))})
+			writeBody(w, rt.status, body)
+			return
+		}
+		writeMessage(w, rt.status, resp)
+	}
+}
+
+func firstOf(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// uploadMedia streams the body of the request to the service, the Content-Type header is the type of the media
+func (g *Gateway) uploadMedia(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := withToken(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		stream, err := g.client.UploadMedia(ctx)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		contentType := r.Header.Get("Content-Type")
+		buffer := make([]byte, media.ChunkSize)
+		for {
+			n, err := r.Body.Read(buffer)
+			if n > 0 {
+				if sendErr := stream.Send(&models.MediaChunk{ContentType: contentType, Data: buffer[:n]}); sendErr != nil {
+					// the actual error is returned by CloseAndRecv
+					break
+				}
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				stream.CloseSend()
+				writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+				return
+			}
+		}
+		uploaded, err := stream.CloseAndRecv()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeMessage(w, rt.status, uploaded)
+	}
+}
+
+// getMedia streams the media to the response, the errors are JSON as long as nothing was written yet
+func (g *Gateway) getMedia(w http.ResponseWriter, r *http.Request) {
+	ctx, err := withToken(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	stream, err := g.client.GetMedia(ctx, &models.Media{MediaID: r.PathValue("MediaID")})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	chunk, err := stream.Recv()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", chunk.ContentType)
+	// media is stored under the hash of its content, so the content never changes
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	for err == nil {
+		if _, writeErr := w.Write(chunk.Data); writeErr != nil {
+			return
+		}
+		chunk, err = stream.Recv()
+	}
+	if err != io.EOF {
+		logging.FromContext(r.Context()).Error("Unable to stream the media", "err", err)
+	}
+}
+
+func writeBody(w http.ResponseWriter, code int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+func writeMessage(w http.ResponseWriter, code int, message proto.Message) {
+	body, err := protojson.Marshal(message)
+	if err != nil {
+		writeError(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+	writeBody(w, code, body)
+}
@@ -0,0 +1,223 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/twitter/idempotency"
+	"github.com/twitter/models"
+	"github.com/twitter/twitter"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeTwitter records the requests made to it
+type fakeTwitter struct {
+	twitter.UnimplementedTwitterServer
+	token          string
+	idempotencyKey string
+	feed           *models.FeedRequest
+}
+
+func (f *fakeTwitter) tokenOf(ctx context.Context) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	f.token = strings.Join(md.Get("token"), ",")
+	f.idempotencyKey = strings.Join(md.Get(idempotency.Key), ",")
+}
+
+func (f *fakeTwitter) LoginUser(ctx context.Context, user *models.User) (*models.User, error) {
+	grpc.SendHeader(ctx, metadata.Pairs("token", "token-of-"+user.UserName))
+	return &models.User{UserName: user.UserName}, nil
+}
+
+func (f *fakeTwitter) GetUser(ctx context.Context, user *models.User) (*models.User, error) {
+	if user.UserName != "alice" {
+		return nil, status.Error(codes.NotFound, "No such user")
+	}
+	return &models.User{UserName: user.UserName, Followers: []string{"bob"}}, nil
+}
+
+func (f *fakeTwitter) CreatePost(ctx context.Context, post *models.Post) (*models.Post, error) {
+	f.tokenOf(ctx)
+	if f.token == "" {
+		return nil, status.Error(codes.Unauthenticated, "Missing token")
+	}
+	post.PostID = "1"
+	return post, nil
+}
+
+func (f *fakeTwitter) UnFollowUser(ctx context.Context, user *models.User) (*models.Empty, error) {
+	return &models.Empty{}, nil
+}
+
+func (f *fakeTwitter) GetFeed(ctx context.Context, request *models.FeedRequest) (*models.MultiplePosts, error) {
+	f.feed = request
+	return &models.MultiplePosts{}, nil
+}
+
+func (f *fakeTwitter) GetMedia(media *models.Media, stream twitter.Twitter_GetMediaServer) error {
+	if media.MediaID != "abc" {
+		return status.Error(codes.NotFound, "No such media")
+	}
+	stream.Send(&models.MediaChunk{ContentType: "image/png", Data: []byte("first")})
+	return stream.Send(&models.MediaChunk{Data: []byte("second")})
+}
+
+func newGateway(t *testing.T) (*Gateway, *fakeTwitter) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	fake := &fakeTwitter{}
+	twitter.RegisterTwitterServer(server, fake)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Error in dialing the fake service: %+v\n", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return New(conn), fake
+}
+
+func serve(gateway *Gateway, method string, target string, body string, token string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	gateway.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestRoutes(t *testing.T) {
+	routed := map[string]bool{}
+	for _, rt := range routes {
+		routed[rt.rpc] = true
+	}
+	for _, method := range twitter.Twitter_ServiceDesc.Methods {
+		if !routed[method.MethodName] {
+			t.Errorf("No endpoint for %+v\n", method.MethodName)
+		}
+	}
+	for _, stream := range twitter.Twitter_ServiceDesc.Streams {
+		if !routed[stream.StreamName] {
+			t.Errorf("No endpoint for %+v\n", stream.StreamName)
+		}
+	}
+}
+
+func TestGateway(t *testing.T) {
+	gateway, fake := newGateway(t)
+
+	recorder := serve(gateway, "POST", "/api/v1/posts", `{"Content": "hello"}`, "secret")
+	post := &models.Post{}
+	protojson.Unmarshal(recorder.Body.Bytes(), post)
+	if recorder.Code != http.StatusCreated || post.PostID != "1" || post.Content != "hello" {
+		t.Errorf("Post not created: %+v %s\n", recorder.Code, recorder.Body.String())
+	}
+	if fake.token != "secret" {
+		t.Errorf("Bearer token not passed on: %+v\n", fake.token)
+	}
+
+	request := httptest.NewRequest("POST", "/api/v1/posts", strings.NewReader(`{"Content": "hello"}`))
+	request.Header.Set("Authorization", "Bearer secret")
+	request.Header.Set(IdempotencyKeyHeader, "key-1")
+	gateway.ServeHTTP(httptest.NewRecorder(), request)
+	if fake.idempotencyKey != "key-1" {
+		t.Errorf("Idempotency key not passed on: %+v\n", fake.idempotencyKey)
+	}
+
+	recorder = serve(gateway, "POST", "/api/v1/posts", `{"Content": "hello"}`, "")
+	if recorder.Code != http.StatusUnauthorized || !strings.Contains(recorder.Body.String(), `"code":"Unauthenticated"`) {
+		t.Errorf("Unauthenticated request not refused: %+v %s\n", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = serve(gateway, "POST", "/api/v1/posts", `{"Content": `, "secret")
+	if recorder.Code != http.StatusBadRequest || recorder.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Malformed body accepted: %+v %s\n", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = serve(gateway, "GET", "/api/v1/users/alice", "", "")
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), `"Followers":["bob"]`) {
+		t.Errorf("User not returned: %+v %s\n", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = serve(gateway, "GET", "/api/v1/users/nobody", "", "")
+	got := apiError{}
+	json.Unmarshal(recorder.Body.Bytes(), &got)
+	if recorder.Code != http.StatusNotFound || got != (apiError{Code: "NotFound", Message: "No such user"}) {
+		t.Errorf("Error not returned as JSON: %+v %s\n", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = serve(gateway, "DELETE", "/api/v1/users/alice/follow", "", "secret")
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "{}" {
+		t.Errorf("Empty response not returned: %+v %s\n", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = serve(gateway, "POST", "/api/v1/sessions", `{"UserName": "alice", "userPassword": "pass"}`, "")
+	if recorder.Code != http.StatusCreated || recorder.Body.String() != `{"token":"token-of-alice"}` {
+		t.Errorf("Token not returned: %+v %s\n", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = serve(gateway, "GET", "/api/v1/me/feed?order=ranked&Options.IncludeReposts=true&options.replies=2", "", "secret")
+	want_feed := &models.FeedRequest{
+		Order:   models.FeedOrder_RANKED,
+		Options: &models.FeedOptions{IncludeReposts: true, Replies: models.ReplyPolicy_REPLIES_NONE},
+	}
+	if recorder.Code != http.StatusOK || !proto.Equal(fake.feed, want_feed) {
+		t.Errorf("Query string not decoded: %+v %+v\n", recorder.Code, fake.feed)
+	}
+
+	recorder = serve(gateway, "GET", "/api/v1/me/feed?order=sideways", "", "secret")
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Invalid parameter accepted: %+v %s\n", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = serve(gateway, "GET", "/api/v1/media/abc", "", "")
+	if recorder.Body.String() != "firstsecond" || recorder.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("Media not streamed: %+v %s\n", recorder.Header(), recorder.Body.String())
+	}
+
+	recorder = serve(gateway, "GET", "/api/v1/nowhere", "", "")
+	if recorder.Code != http.StatusNotFound || !strings.Contains(recorder.Body.String(), `"code":"NotFound"`) {
+		t.Errorf("Unknown endpoint not reported as JSON: %+v %s\n", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	gateway, _ := newGateway(t)
+	recorder := serve(gateway, "GET", "/api/v1/openapi.json", "", "")
+	var document struct {
+		Paths      map[string]map[string]struct{ OperationID string }
+		Components struct{ Schemas map[string]any }
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &document); err != nil {
+		t.Fatalf("Error in decoding the document: %+v\n", err)
+	}
+	for _, rt := range routes {
+		if document.Paths[rt.path][strings.ToLower(rt.method)].OperationID != rt.rpc {
+			t.Errorf("Endpoint missing from the document: %+v %+v\n", rt.method, rt.path)
+		}
+	}
+	for _, schema := range []string{"Post", "Poll", "PollVote", "Error"} {
+		if _, ok := document.Components.Schemas[schema]; !ok {
+			t.Errorf("Schema missing from the document: %+v\n", schema)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package gateway
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/twitter/idempotency"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// object is a JSON object of the OpenAPI document
+type object = map[string]any
+
+var timestampName = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
+
+// schemas collects the schemas of the messages referenced by the document
+type schemas object
+
+// ref returns the reference to the schema of the message, adding the schemas of the message and of the messages it
+// references
+func (s schemas) ref(desc protoreflect.MessageDescriptor) object {
+	name := string(desc.Name())
+	if _, ok := s[name]; !ok {
+		properties := object{}
+		s[name] = object{"type": "object", "properties": properties}
+		fields := desc.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			properties[fields.Get(i).JSONName()] = s.field(fields.Get(i))
+		}
+	}
+	return object{"$ref": "#/components/schemas/" + name}
+}
+
+// field returns the schema of the field as protojson encodes it
+func (s schemas) field(field protoreflect.FieldDescriptor) object {
+	var schema object
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		schema = object{"type": "string"}
+	case protoreflect.BytesKind:
+		schema = object{"type": "string", "format": "byte"}
+	case protoreflect.BoolKind:
+		schema = object{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		schema = object{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		schema = object{"type": "integer", "format": "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// 64 bit integers are strings so that they aren't rounded by JavaScript clients
+		schema = object{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		schema = object{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		schema = object{"type": "number", "format": "double"}
+	case protoreflect.EnumKind:
+		var names []string
+		values := field.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		schema = object{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if field.Message().FullName() == timestampName {
+			schema = object{"type": "string", "format": "date-time"}
+		} else {
+			schema = s.ref(field.Message())
+		}
+	}
+	if field.IsList() {
+		return object{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+// queryParameters returns the parameters of the fields of the message that can be set from the query string,
+// named by their dotted path
+func (s schemas) queryParameters(desc protoreflect.MessageDescriptor, prefix string, seen map[protoreflect.FullName]bool) []any {
+	if seen[desc.FullName()] {
+		return nil
+	}
+	seen[desc.FullName()] = true
+	defer delete(seen, desc.FullName())
+	var parameters []any
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		name := prefix + string(field.Name())
+		if field.Kind() == protoreflect.MessageKind && !field.IsList() && !field.IsMap() {
+			parameters = append(parameters, s.queryParameters(field.Message(), name+".", seen)...)
+			continue
+		}
+		if !queryable(field) {
+			continue
+		}
+		parameters = append(parameters, object{"name": name, "in": "query", "schema": s.field(field)})
+	}
+	return parameters
+}
+
+func jsonContent(schema object) object {
+	return object{"application/json": object{"schema": schema}}
+}
+
+// operation describes the endpoint of the route
+func (s schemas) operation(rt route, method protoreflect.MethodDescriptor) object {
+	var parameters []any
+	for _, name := range rt.wildcards() {
+		parameters = append(parameters, object{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   s.field(findField(method.Input(), name)),
+		})
+	}
+	if idempotency.Accepts("/" + string(service().FullName()) + "/" + rt.rpc) {
+		parameters = append(parameters, object{
+			"name":        IdempotencyKeyHeader,
+			"in":          "header",
+			"description": "repeating the request with the same key returns the response of the first one",
+			"schema":      object{"type": "string", "maxLength": idempotency.MaxKeyLength},
+		})
+	}
+	operation := object{
+		"operationId": rt.rpc,
+		"tags":        []string{strings.Split(strings.TrimPrefix(rt.path, "/"), "/")[0]},
+		"responses": object{
+			"default": object{"description": "Error", "content": jsonContent(object{"$ref": "#/components/schemas/Error"})},
+		},
+	}
+	response := object{"description": http.StatusText(rt.status)}
+	switch {
+	case rt.rpc == "UploadMedia":
+		operation["requestBody"] = object{
+			"required": true,
+			"content":  object{"image/*": object{"schema": object{"type": "string", "format": "binary"}}},
+		}
+		response["content"] = jsonContent(s.ref(method.Output()))
+	case rt.rpc == "GetMedia":
+		response["content"] = object{"image/*": object{"schema": object{"type": "string", "format": "binary"}}}
+	case rt.rpc == "LoginUser":
+		operation["requestBody"] = object{"required": true, "content": jsonContent(s.ref(method.Input()))}
+		response["content"] = jsonContent(object{
+			"type":       "object",
+			"properties": object{"token": object{"type": "string"}},
+		})
+	case rt.hasBody():
+		operation["requestBody"] = object{"content": jsonContent(s.ref(method.Input()))}
+		response["content"] = jsonContent(s.ref(method.Output()))
+	default:
+		// the resources of the routes with a path are identified by the path alone
+		if len(rt.wildcards()) == 0 {
+			parameters = append(parameters, s.queryParameters(method.Input(), "", map[protoreflect.FullName]bool{})...)
+		}
+		response["content"] = jsonContent(s.ref(method.Output()))
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+	operation["responses"].(object)[strconv.Itoa(rt.status)] = response
+	return operation
+}
+
+// openAPI generates the OpenAPI document of the routes, the schemas are those of the messages of the proto
+func openAPI(routes []route) object {
+	s := schemas{
+		"Error": object{
+			"type": "object",
+			"properties": object{
+				"code":    object{"type": "string", "description": "name of the gRPC status code"},
+				"message": object{"type": "string"},
+			},
+		},
+	}
+	methods := service().Methods()
+	paths := object{}
+	for _, rt := range routes {
+		item, ok := paths[rt.path].(object)
+		if !ok {
+			item = object{}
+			paths[rt.path] = item
+		}
+		item[strings.ToLower(rt.method)] = s.operation(rt, methods.ByName(protoreflect.Name(rt.rpc)))
+	}
+	return object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":   "Twitter API",
+			"version": "v1",
+		},
+		"servers": []any{object{"url": Prefix}},
+		// the token is the one returned by POST /sessions, requests without one are made anonymously
+		"security": []any{object{"bearer": []string{}}, object{}},
+		"paths":    paths,
+		"components": object{
+			"schemas":         object(s),
+			"securitySchemes": object{"bearer": object{"type": "http", "scheme": "bearer"}},
+		},
+	}
+}
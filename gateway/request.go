@@ -0,0 +1,166 @@
+package gateway
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// decodeRequest fills in the request from the body or query string, then from the wildcards of the path, which
+// take precedence
+func decodeRequest(w http.ResponseWriter, r *http.Request, rt route, req proto.Message) error {
+	if rt.hasBody() {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if len(body) > 0 {
+			if err := protojson.Unmarshal(body, req); err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+	} else {
+		for name, values := range r.URL.Query() {
+			if err := setField(req.ProtoReflect(), name, values); err != nil {
+				return err
+			}
+		}
+	}
+	for _, name := range rt.wildcards() {
+		if err := setField(req.ProtoReflect(), name, []string{r.PathValue(name)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findField finds the field by its proto or JSON name, ignoring case
+func findField(desc protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if strings.EqualFold(string(field.Name()), name) || strings.EqualFold(field.JSONName(), name) {
+			return field
+		}
+	}
+	return nil
+}
+
+// setField sets the field named by the dotted path, such as Options.IncludeReposts, to the values. Repeated fields
+// are set to all the values, the others to the last one.
+func setField(message protoreflect.Message, path string, values []string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		field := findField(message.Descriptor(), name)
+		if field == nil {
+			return status.Errorf(codes.InvalidArgument, "Unknown parameter %s", path)
+		}
+		if i < len(names)-1 {
+			if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+				return status.Errorf(codes.InvalidArgument, "Unknown parameter %s", path)
+			}
+			message = message.Mutable(field).Message()
+			continue
+		}
+		if field.IsMap() {
+			return status.Errorf(codes.InvalidArgument, "Parameter %s can't be set from the URL", path)
+		}
+		if field.IsList() {
+			list := message.Mutable(field).List()
+			for _, value := range values {
+				parsed, err := parseValue(field, path, value)
+				if err != nil {
+					return err
+				}
+				list.Append(parsed)
+			}
+			return nil
+		}
+		parsed, err := parseValue(field, path, values[len(values)-1])
+		if err != nil {
+			return err
+		}
+		message.Set(field, parsed)
+	}
+	return nil
+}
+
+// queryable reports whether the field can be set from a parameter of the URL
+func queryable(field protoreflect.FieldDescriptor) bool {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind, protoreflect.BytesKind:
+		return false
+	}
+	return !field.IsMap()
+}
+
+// parseValue parses a parameter of the URL into a value of the field, enums are given by name or number
+func parseValue(field protoreflect.FieldDescriptor, path string, value string) (protoreflect.Value, error) {
+	invalid := status.Errorf(codes.InvalidArgument, "Invalid value %q of parameter %s", value, path)
+	if !queryable(field) {
+		return protoreflect.Value{}, status.Errorf(codes.InvalidArgument, "Parameter %s can't be set from the URL", path)
+	}
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BoolKind:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfBool(parsed), nil
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByName(protoreflect.Name(strings.ToUpper(value))); enumValue != nil {
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+		parsed, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || field.Enum().Values().ByNumber(protoreflect.EnumNumber(parsed)) == nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(parsed)), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		parsed, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfInt32(int32(parsed)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfInt64(parsed), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfUint32(uint32(parsed)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfUint64(parsed), nil
+	case protoreflect.FloatKind:
+		parsed, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfFloat32(float32(parsed)), nil
+	case protoreflect.DoubleKind:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return protoreflect.Value{}, invalid
+		}
+		return protoreflect.ValueOfFloat64(parsed), nil
+	}
+	return protoreflect.Value{}, invalid
+}
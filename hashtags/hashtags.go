@@ -0,0 +1,148 @@
+package hashtags
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+)
+
+const (
+	DefaultTrendingWindow = 24 * time.Hour
+	MaxTrendingWindow     = 7 * 24 * time.Hour
+	DefaultTrendingLimit  = 10
+)
+
+type Service interface {
+	// IndexPost adds the post to the index of every hashtag it carries
+	IndexPost(ctx context.Context, post *models.Post) error
+	// RemovePost removes the post from the index of every hashtag it carries
+	RemovePost(ctx context.Context, post *models.Post) error
+	// GetTimeline returns the posts carrying the hashtag, newest first
+	GetTimeline(ctx context.Context, tag string) ([]*models.Post, error)
+	// GetTrending returns the most used hashtags within the given window
+	GetTrending(ctx context.Context, window time.Duration, limit int) ([]*models.TrendingTag, error)
+}
+
+type HashtagService struct {
+	db storage.Storage
+}
+
+func isTagRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Normalize strips the leading '#' and lowercases the tag
+func Normalize(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// Extract returns the distinct, normalized hashtags in the content in the order they first appear
+func Extract(content string) []string {
+	tags := make([]string, 0)
+	seen := make(map[string]bool)
+	runes := []rune(content)
+	for idx := 0; idx < len(runes); idx++ {
+		if runes[idx] != '#' || (idx > 0 && (isTagRune(runes[idx-1]) || runes[idx-1] == '#')) {
+			continue
+		}
+		end := idx + 1
+		for end < len(runes) && isTagRune(runes[end]) {
+			end++
+		}
+		if end == idx+1 {
+			continue
+		}
+		tag := strings.ToLower(string(runes[idx+1 : end]))
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+		idx = end - 1
+	}
+	return tags
+}
+
+func (hs *HashtagService) IndexPost(ctx context.Context, post *models.Post) error {
+	for _, tag := range post.Hashtags {
+		if err := hs.db.HashtagStore().AddPost(ctx, tag, post); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (hs *HashtagService) RemovePost(ctx context.Context, post *models.Post) error {
+	for _, tag := range post.Hashtags {
+		if err := hs.db.HashtagStore().RemovePost(ctx, tag, post); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (hs *HashtagService) GetTimeline(ctx context.Context, tag string) ([]*models.Post, error) {
+	postIds, err := hs.db.HashtagStore().GetPostIDs(ctx, Normalize(tag))
+	if err != nil {
+		return nil, err
+	}
+	timeline := make([]*models.Post, 0, len(postIds))
+	for _, postId := range postIds {
+		post, err := hs.db.PostStore().GetPost(ctx, postId)
+		if errors.Is(err, storage.ErrPostNotFound) {
+			// the post was deleted after it was indexed
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		timeline = append(timeline, post)
+	}
+	sort.Slice(timeline, func(i int, j int) bool {
+		return timeline[i].PostedAt.AsTime().After(timeline[j].PostedAt.AsTime())
+	})
+	return timeline, nil
+}
+
+func (hs *HashtagService) GetTrending(ctx context.Context, window time.Duration, limit int) ([]*models.TrendingTag, error) {
+	if window <= 0 {
+		window = DefaultTrendingWindow
+	}
+	if window > MaxTrendingWindow {
+		window = MaxTrendingWindow
+	}
+	if limit <= 0 {
+		limit = DefaultTrendingLimit
+	}
+	counts, err := hs.db.HashtagStore().GetTrendCounts(ctx, time.Now().Add(-window))
+	if err != nil {
+		return nil, err
+	}
+	trending := make([]*models.TrendingTag, 0, len(counts))
+	for tag, count := range counts {
+		if count > 0 {
+			trending = append(trending, &models.TrendingTag{Tag: tag, Count: count})
+		}
+	}
+	sort.Slice(trending, func(i int, j int) bool {
+		if trending[i].Count != trending[j].Count {
+			return trending[i].Count > trending[j].Count
+		}
+		return trending[i].Tag < trending[j].Tag
+	})
+	if len(trending) > limit {
+		trending = trending[:limit]
+	}
+	return trending, nil
+}
+
+func New(db storage.Storage) Service {
+	return &HashtagService{
+		db: db,
+	}
+}
@@ -0,0 +1,114 @@
+package hashtags
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+	"github.com/twitter/storage/memory"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestExtract(t *testing.T) {
+	test_cases := map[string][]string{
+		"":                                {},
+		"no tags here":                    {},
+		"#Go is fun":                      {"go"},
+		"learning #go and #GO again":      {"go"},
+		"#one,#two.#three":                {"one", "two", "three"},
+		"email@host#notatag and ##double": {},
+		"# lonely hash":                   {},
+		"#snake_case #mixed123 #ünïcode":  {"snake_case", "mixed123", "ünïcode"},
+	}
+	for content, expected_tags := range test_cases {
+		extracted_tags := Extract(content)
+		if !reflect.DeepEqual(extracted_tags, expected_tags) {
+			t.Errorf("Extract(%q) = %+v, expected %+v\n", content, extracted_tags, expected_tags)
+		}
+	}
+}
+
+func TestHashtagService_GetTrending(t *testing.T) {
+	ctx := context.Background()
+	test_storage := memory.New()
+	test_hashtag_service := New(test_storage)
+	contents := []string{"#go #etcd", "#go", "#go #raft", "#etcd"}
+	for _, content := range contents {
+		post, _ := test_storage.PostStore().CreatePost(ctx, &models.Post{
+			PostedBy: "test1",
+			Content:  content,
+			Hashtags: Extract(content),
+			PostedAt: timestamppb.Now(),
+		})
+		if err := test_hashtag_service.IndexPost(ctx, post); err != nil {
+			t.Errorf("Error in indexing post: %+v\n", err)
+		}
+	}
+	old_post, _ := test_storage.PostStore().CreatePost(ctx, &models.Post{
+		PostedBy: "test1",
+		Content:  "#raft",
+		Hashtags: []string{"raft"},
+		PostedAt: timestamppb.New(time.Now().Add(-48 * time.Hour)),
+	})
+	test_hashtag_service.IndexPost(ctx, old_post)
+
+	trending, err := test_hashtag_service.GetTrending(ctx, time.Hour, 2)
+	if err != nil {
+		t.Errorf("Error in getting trending tags: %+v\n", err)
+	}
+	if len(trending) != 2 {
+		t.Fatalf("Expected 2 trending tags, got: %+v\n", trending)
+	}
+	if trending[0].Tag != "go" || trending[0].Count != 3 {
+		t.Errorf("Unexpected top trending tag: %+v\n", trending[0])
+	}
+	if trending[1].Tag != "etcd" || trending[1].Count != 2 {
+		t.Errorf("Unexpected second trending tag: %+v\n", trending[1])
+	}
+
+	timeline, err := test_hashtag_service.GetTimeline(ctx, "#Raft")
+	if err != nil {
+		t.Errorf("Error in getting hashtag timeline: %+v\n", err)
+	}
+	if len(timeline) != 2 || timeline[1].PostID != old_post.PostID {
+		t.Errorf("Hashtag timeline not sorted newest first: %+v\n", timeline)
+	}
+
+	test_hashtag_service.RemovePost(ctx, old_post)
+	timeline, _ = test_hashtag_service.GetTimeline(ctx, "raft")
+	if len(timeline) != 1 {
+		t.Errorf("Removed post still in hashtag timeline: %+v\n", timeline)
+	}
+
+	test_storage.PostStore().DeletePost(ctx, timeline[0])
+	timeline, err = test_hashtag_service.GetTimeline(ctx, "raft")
+	if err != nil || len(timeline) != 0 {
+		t.Errorf("Deleted post still in hashtag timeline: %+v, %+v\n", timeline, err)
+	}
+
+	unreachable_service := New(unreachablePosts{test_storage})
+	if _, err := unreachable_service.GetTimeline(ctx, "go"); err == nil {
+		t.Error("Posts dropped from hashtag timeline when they couldn't be read")
+	}
+}
+
+// unreachablePosts is a storage whose posts can't be read, like etcd timing out
+type unreachablePosts struct {
+	storage.Storage
+}
+
+func (u unreachablePosts) PostStore() storage.PostStore {
+	return failingPostStore{u.Storage.PostStore()}
+}
+
+type failingPostStore struct {
+	storage.PostStore
+}
+
+func (failingPostStore) GetPost(ctx context.Context, postId string) (*models.Post, error) {
+	return nil, errors.New("context deadline exceeded")
+}
@@ -0,0 +1,75 @@
+// Package health reports whether the twitter server is able to serve through the standard gRPC health service
+package health
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/twitter/storage"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Monitor keeps the status of the services up to date with whether the storage is reachable
+type Monitor struct {
+	server   *grpchealth.Server
+	db       storage.Storage
+	services []string
+	timeout  time.Duration
+}
+
+// NewMonitor creates the monitor of the named services, along with the overall status of the server under the
+// empty service name. The services are NOT_SERVING until the first check.
+func NewMonitor(db storage.Storage, timeout time.Duration, services ...string) *Monitor {
+	monitor := &Monitor{
+		server:   grpchealth.NewServer(),
+		db:       db,
+		services: append([]string{""}, services...),
+		timeout:  timeout,
+	}
+	monitor.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return monitor
+}
+
+// Server returns the health service to register on the gRPC server
+func (m *Monitor) Server() healthpb.HealthServer {
+	return m.server
+}
+
+func (m *Monitor) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range m.services {
+		m.server.SetServingStatus(service, status)
+	}
+}
+
+// Check pings the storage once and updates the status of the services accordingly
+func (m *Monitor) Check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+	if err := m.db.Ping(ctx); err != nil {
+		slog.Warn("Storage unreachable, not serving", "err", err)
+		m.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+		return
+	}
+	m.setStatus(healthpb.HealthCheckResponse_SERVING)
+}
+
+// Run checks the storage every interval until ctx is done
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		m.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown reports every service as NOT_SERVING for good, later checks no longer change the status
+func (m *Monitor) Shutdown() {
+	m.server.Shutdown()
+}
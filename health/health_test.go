@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/twitter/storage"
+	"github.com/twitter/storage/memory"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// unreachable is a storage whose ping fails while down is set
+type unreachable struct {
+	storage.Storage
+	down bool
+}
+
+func (u *unreachable) Ping(ctx context.Context) error {
+	if u.down {
+		return errors.New("connection refused")
+	}
+	return u.Storage.Ping(ctx)
+}
+
+func statusOf(t *testing.T, monitor *Monitor, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := monitor.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Error in checking the health of %+v: %+v\n", service, err)
+	}
+	return resp.Status
+}
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+	db := &unreachable{Storage: memory.New()}
+	defer db.Close()
+	monitor := NewMonitor(db, time.Second, "twitter.Twitter")
+	if status := statusOf(t, monitor, "twitter.Twitter"); status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Serving before the first check: %+v\n", status)
+	}
+
+	monitor.Check(ctx)
+	for _, service := range []string{"", "twitter.Twitter"} {
+		if status := statusOf(t, monitor, service); status != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Service %+v not serving with the storage reachable: %+v\n", service, status)
+		}
+	}
+
+	db.down = true
+	monitor.Check(ctx)
+	if status := statusOf(t, monitor, "twitter.Twitter"); status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Serving with the storage unreachable: %+v\n", status)
+	}
+
+	db.down = false
+	monitor.Check(ctx)
+	monitor.Shutdown()
+	monitor.Check(ctx)
+	if status := statusOf(t, monitor, ""); status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Serving after shutdown: %+v\n", status)
+	}
+}
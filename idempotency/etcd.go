@@ -0,0 +1,106 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const etcdPrefix = "twitter-key-idempotency"
+
+// sharedLease is a lease the records are put under, replaced once half of it passed so that every record outlives
+// its ttl by at most half of it without granting a lease per record
+type sharedLease struct {
+	ttl     time.Duration
+	mtx     sync.Mutex
+	lease   clientv3.LeaseID
+	renewAt time.Time
+}
+
+func (s *sharedLease) current(ctx context.Context, client *clientv3.Client, now time.Time) (clientv3.LeaseID, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.lease != clientv3.NoLease && now.Before(s.renewAt) {
+		return s.lease, nil
+	}
+	resp, err := client.Grant(ctx, int64((s.ttl + s.ttl/2).Seconds()))
+	if err != nil {
+		return clientv3.NoLease, err
+	}
+	s.lease, s.renewAt = resp.ID, now.Add(s.ttl/2)
+	return s.lease, nil
+}
+
+// etcdStore keeps the records in etcd, so that an RPC repeated on another server is replayed too
+type etcdStore struct {
+	client    *clientv3.Client
+	pending   *sharedLease
+	completed *sharedLease
+}
+
+// NewEtcdStore creates the store of the records shared by the servers using the etcd cluster, the responses are kept
+// for ttl
+func NewEtcdStore(client *clientv3.Client, ttl time.Duration) Store {
+	return &etcdStore{
+		client:    client,
+		pending:   &sharedLease{ttl: pendingTTL},
+		completed: &sharedLease{ttl: ttl},
+	}
+}
+
+// Reserve puts the reservation in a transaction that only succeeds if no record is there yet, and reads the record
+// otherwise
+func (e *etcdStore) Reserve(ctx context.Context, key string, record Record, now time.Time) (*Record, error) {
+	etcdKey := etcdPrefix + "/" + key
+	lease, err := e.pending.current(ctx, e.client, now)
+	if err != nil {
+		return nil, err
+	}
+	value, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	txn, err := e.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(etcdKey), "=", 0)).
+		Then(clientv3.OpPut(etcdKey, string(value), clientv3.WithLease(lease))).
+		Else(clientv3.OpGet(etcdKey)).
+		Commit()
+	if err != nil {
+		return nil, err
+	}
+	if txn.Succeeded {
+		return nil, nil
+	}
+	stored := &Record{}
+	kvs := txn.Responses[0].GetResponseRange().Kvs
+	if len(kvs) == 0 {
+		// the transaction is atomic so the record is there, it's treated as the request still in progress otherwise
+		stored.RequestHash = record.RequestHash
+		return stored, nil
+	}
+	if err := json.Unmarshal(kvs[0].Value, stored); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (e *etcdStore) Complete(ctx context.Context, key string, record Record, now time.Time) error {
+	lease, err := e.completed.current(ctx, e.client, now)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = e.client.Put(ctx, etcdPrefix+"/"+key, string(value), clientv3.WithLease(lease))
+	return err
+}
+
+func (e *etcdStore) Release(ctx context.Context, key string) error {
+	_, err := e.client.Delete(ctx, etcdPrefix+"/"+key)
+	return err
+}
@@ -0,0 +1,200 @@
+// Package idempotency lets the clients repeat a write RPC with the same idempotency key without repeating its effect,
+// the server stores the response of the first RPC and returns it again to the repeated ones
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/twitter/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// Key is the metadata the clients send the idempotency key in
+	Key = "idempotency-key"
+	// MaxKeyLength is the length of the longest key accepted, a UUID fits comfortably
+	MaxKeyLength = 128
+)
+
+// methods are the write RPCs that accept an idempotency key, UploadMedia is a stream and isn't replayed
+var methods = map[string]bool{
+	"/twitter.Twitter/RegisterUser":     true,
+	"/twitter.Twitter/FollowUser":       true,
+	"/twitter.Twitter/UnFollowUser":     true,
+	"/twitter.Twitter/CreatePost":       true,
+	"/twitter.Twitter/DeletePost":       true,
+	"/twitter.Twitter/EditPost":         true,
+	"/twitter.Twitter/SchedulePost":     true,
+	"/twitter.Twitter/BookmarkPost":     true,
+	"/twitter.Twitter/RemoveBookmark":   true,
+	"/twitter.Twitter/PinPost":          true,
+	"/twitter.Twitter/UnpinPost":        true,
+	"/twitter.Twitter/VotePoll":         true,
+	"/twitter.Twitter/CreateList":       true,
+	"/twitter.Twitter/UpdateList":       true,
+	"/twitter.Twitter/DeleteList":       true,
+	"/twitter.Twitter/AddListMember":    true,
+	"/twitter.Twitter/RemoveListMember": true,
+	"/twitter.Twitter/BlockUser":        true,
+	"/twitter.Twitter/UnBlockUser":      true,
+	"/twitter.Twitter/Repost":           true,
+	"/twitter.Twitter/UnRepost":         true,
+}
+
+// Accepts reports whether the RPC of the full method accepts an idempotency key
+func Accepts(fullMethod string) bool {
+	return methods[fullMethod]
+}
+
+// Record is what's stored under a key
+type Record struct {
+	// Done is false while the first RPC with the key runs
+	Done bool `json:"done"`
+	// Response is the marshaled anypb.Any of the response of the first RPC
+	Response []byte `json:"response,omitempty"`
+	// RequestHash is the hash of the request of the first RPC, the key can't be reused for another request
+	RequestHash string `json:"requestHash,omitempty"`
+}
+
+// Store keeps the records of the keys for as long as the RPCs with them are replayed
+type Store interface {
+	// Reserve stores the record of an RPC that isn't done under the key unless there's one already, which is returned
+	// instead
+	Reserve(ctx context.Context, key string, record Record, now time.Time) (*Record, error)
+	// Complete stores the record of the response of the RPC that reserved the key
+	Complete(ctx context.Context, key string, record Record, now time.Time) error
+	// Release removes the reservation of an RPC that failed, so that it can be repeated
+	Release(ctx context.Context, key string) error
+}
+
+// Identify returns who made the request, the keys of different identities never clash
+type Identify func(ctx context.Context) string
+
+type Replayer struct {
+	store    Store
+	identify Identify
+	now      func() time.Time
+}
+
+// New creates the replayer of the write RPCs keeping the records of their keys in the store
+func New(store Store, identify Identify) *Replayer {
+	return &Replayer{
+		store:    store,
+		identify: identify,
+		now:      time.Now,
+	}
+}
+
+// keyOf returns the key of the RPC the store is asked about, empty when the RPC wasn't sent with one
+func (r *Replayer) keyOf(ctx context.Context, fullMethod string) (string, error) {
+	if !Accepts(fullMethod) {
+		return "", nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(Key)
+	if len(keys) == 0 || keys[0] == "" {
+		return "", nil
+	}
+	if len(keys[0]) > MaxKeyLength {
+		return "", status.Errorf(codes.InvalidArgument, "Idempotency key longer than %d characters", MaxKeyLength)
+	}
+	return strings.TrimPrefix(fullMethod, "/") + "/" + r.identify(ctx) + "/" + keys[0], nil
+}
+
+// UnaryServerInterceptor runs the first RPC with a key and returns its response to the RPCs repeated with the key. An
+// RPC repeated while the first one runs is refused with Aborted, and one with another request than the first one with
+// InvalidArgument. The RPCs that fail aren't stored, and the RPCs run anyway when the store fails so that the service
+// doesn't go down with it.
+func (r *Replayer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		key, err := r.keyOf(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if key == "" {
+			return handler(ctx, req)
+		}
+		requestHash, err := hashOf(req)
+		if err != nil {
+			return nil, err
+		}
+		logger := logging.FromContext(ctx)
+		record, err := r.store.Reserve(ctx, key, Record{RequestHash: requestHash}, r.now())
+		if err != nil {
+			logger.Warn("Unable to reserve the idempotency key, running the request", "err", err)
+			return handler(ctx, req)
+		}
+		if record != nil {
+			if record.RequestHash != requestHash {
+				return nil, status.Error(codes.InvalidArgument, "Idempotency key already used for another request")
+			}
+			return replay(record)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			if releaseErr := r.store.Release(ctx, key); releaseErr != nil {
+				logger.Warn("Unable to release the idempotency key", "err", releaseErr)
+			}
+			return nil, err
+		}
+		if err := r.complete(ctx, key, requestHash, resp); err != nil {
+			logger.Warn("Unable to store the response of the idempotency key", "err", err)
+		}
+		return resp, nil
+	}
+}
+
+// hashOf returns the hash of the request, the same for every equal request
+func hashOf(req interface{}) (string, error) {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return "", status.Errorf(codes.Internal, "Request of type %T isn't a message", req)
+	}
+	marshaled, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return "", status.Error(codes.Internal, "Unable to read the request")
+	}
+	hash := sha256.Sum256(marshaled)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func (r *Replayer) complete(ctx context.Context, key string, requestHash string, resp interface{}) error {
+	message, ok := resp.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "Response of type %T isn't a message", resp)
+	}
+	response, err := anypb.New(message)
+	if err != nil {
+		return err
+	}
+	marshaled, err := proto.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return r.store.Complete(ctx, key, Record{Done: true, Response: marshaled, RequestHash: requestHash}, r.now())
+}
+
+func replay(record *Record) (interface{}, error) {
+	if !record.Done {
+		return nil, status.Error(codes.Aborted, "Request with the same idempotency key still in progress")
+	}
+	response := &anypb.Any{}
+	if err := proto.Unmarshal(record.Response, response); err != nil {
+		return nil, status.Error(codes.Internal, "Unable to read the stored response")
+	}
+	message, err := response.UnmarshalNew()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Unable to read the stored response")
+	}
+	return message, nil
+}
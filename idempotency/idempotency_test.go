@@ -0,0 +1,145 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/twitter"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// failingStore is a store that can't be reached
+type failingStore struct{}
+
+func (failingStore) Reserve(ctx context.Context, key string, record Record, now time.Time) (*Record, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (failingStore) Complete(ctx context.Context, key string, record Record, now time.Time) error {
+	return errors.New("connection refused")
+}
+
+func (failingStore) Release(ctx context.Context, key string) error {
+	return errors.New("connection refused")
+}
+
+func TestMethods(t *testing.T) {
+	unary := map[string]bool{}
+	for _, method := range twitter.Twitter_ServiceDesc.Methods {
+		unary["/"+twitter.Twitter_ServiceDesc.ServiceName+"/"+method.MethodName] = true
+	}
+	for method := range methods {
+		if !unary[method] {
+			t.Errorf("No such unary method: %+v\n", method)
+		}
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(time.Hour).(*memoryStore)
+	now := time.Now()
+
+	if record, _ := store.Reserve(ctx, "a", Record{}, now); record != nil {
+		t.Errorf("Unused key already reserved: %+v\n", record)
+	}
+	if record, _ := store.Reserve(ctx, "a", Record{}, now); record == nil || record.Done {
+		t.Errorf("Reservation not returned: %+v\n", record)
+	}
+	if record, _ := store.Reserve(ctx, "a", Record{}, now.Add(pendingTTL)); record != nil {
+		t.Errorf("Reservation not expired: %+v\n", record)
+	}
+	store.Complete(ctx, "a", Record{Done: true, Response: []byte("response")}, now)
+	if record, _ := store.Reserve(ctx, "a", Record{}, now.Add(pendingTTL)); record == nil || string(record.Response) != "response" {
+		t.Errorf("Response not returned: %+v\n", record)
+	}
+	store.Release(ctx, "a")
+	if record, _ := store.Reserve(ctx, "a", Record{}, now); record != nil {
+		t.Errorf("Released key still reserved: %+v\n", record)
+	}
+
+	store.Reserve(ctx, "b", Record{}, now.Add(time.Hour+sweepInterval))
+	if _, ok := store.entries["a"]; ok {
+		t.Error("Expired record not swept")
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	identify := func(ctx context.Context) string {
+		md, _ := metadata.FromIncomingContext(ctx)
+		return "user/" + md.Get("user")[0]
+	}
+	replayer := New(NewMemoryStore(time.Hour), identify)
+	interceptor := replayer.UnaryServerInterceptor()
+	created := 0
+	var fail error
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		if fail != nil {
+			return nil, fail
+		}
+		created++
+		return &models.Post{PostID: "post", Content: req.(*models.Post).Content}, nil
+	}
+	content := "hello"
+	call := func(method string, user string, key string) (*models.Post, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user", user, Key, key))
+		resp, err := interceptor(ctx, &models.Post{Content: content}, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*models.Post), nil
+	}
+
+	first, err := call("/twitter.Twitter/CreatePost", "alice", "1")
+	if err != nil {
+		t.Fatalf("Error in the first request: %+v\n", err)
+	}
+	replayed, err := call("/twitter.Twitter/CreatePost", "alice", "1")
+	if err != nil || !proto.Equal(first, replayed) || created != 1 {
+		t.Errorf("Request not replayed: %+v %+v %+v\n", replayed, err, created)
+	}
+	content = "goodbye"
+	if _, err := call("/twitter.Twitter/CreatePost", "alice", "1"); status.Code(err) != codes.InvalidArgument || created != 1 {
+		t.Errorf("Key reused for another request not refused: %+v %+v\n", err, created)
+	}
+	content = "hello"
+	call("/twitter.Twitter/CreatePost", "bob", "1")
+	call("/twitter.Twitter/CreatePost", "alice", "2")
+	call("/twitter.Twitter/CreatePost", "alice", "")
+	call("/twitter.Twitter/GetPost", "alice", "1")
+	if created != 5 {
+		t.Errorf("Requests with different keys replayed: %+v\n", created)
+	}
+
+	fail = status.Error(codes.Unavailable, "storage down")
+	if _, err := call("/twitter.Twitter/CreatePost", "alice", "3"); status.Code(err) != codes.Unavailable {
+		t.Errorf("Error not returned: %+v\n", err)
+	}
+	fail = nil
+	if _, err := call("/twitter.Twitter/CreatePost", "alice", "3"); err != nil || created != 6 {
+		t.Errorf("Failed request not repeated: %+v %+v\n", err, created)
+	}
+
+	requestHash, _ := hashOf(&models.Post{Content: content})
+	replayer.store.Reserve(context.Background(), "twitter.Twitter/CreatePost/user/alice/4", Record{RequestHash: requestHash}, time.Now())
+	if _, err := call("/twitter.Twitter/CreatePost", "alice", "4"); status.Code(err) != codes.Aborted {
+		t.Errorf("Request in progress not refused: %+v\n", err)
+	}
+	if _, err := call("/twitter.Twitter/CreatePost", "alice", strings.Repeat("k", MaxKeyLength+1)); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Long key accepted: %+v\n", err)
+	}
+
+	failing := New(failingStore{}, identify).UnaryServerInterceptor()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user", "alice", Key, "1"))
+	if _, err := failing(ctx, &models.Post{}, &grpc.UnaryServerInfo{FullMethod: "/twitter.Twitter/CreatePost"}, handler); err != nil {
+		t.Errorf("Request refused when the store failed: %+v\n", err)
+	}
+}
@@ -0,0 +1,69 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// pendingTTL is how long a key stays reserved by an RPC that didn't complete, such as one of a server that crashed
+	pendingTTL = time.Minute
+	// sweepInterval is how often the expired records are dropped
+	sweepInterval = time.Minute
+)
+
+type entry struct {
+	record    Record
+	expiresAt time.Time
+}
+
+// memoryStore keeps the records of a single server
+type memoryStore struct {
+	ttl       time.Duration
+	mtx       sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates the store of the records that only hold within the server, the responses are kept for ttl
+func NewMemoryStore(ttl time.Duration) Store {
+	return &memoryStore{ttl: ttl, entries: make(map[string]*entry)}
+}
+
+func (m *memoryStore) Reserve(ctx context.Context, key string, record Record, now time.Time) (*Record, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if now.Sub(m.lastSweep) > sweepInterval {
+		m.sweep(now)
+	}
+	if e, ok := m.entries[key]; ok && now.Before(e.expiresAt) {
+		stored := e.record
+		return &stored, nil
+	}
+	m.entries[key] = &entry{record: record, expiresAt: now.Add(pendingTTL)}
+	return nil, nil
+}
+
+func (m *memoryStore) Complete(ctx context.Context, key string, record Record, now time.Time) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.entries[key] = &entry{record: record, expiresAt: now.Add(m.ttl)}
+	return nil
+}
+
+func (m *memoryStore) Release(ctx context.Context, key string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+func (m *memoryStore) sweep(now time.Time) {
+	for key, e := range m.entries {
+		if !now.Before(e.expiresAt) {
+			delete(m.entries, key)
+		}
+	}
+	m.lastSweep = now
+}
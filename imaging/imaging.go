@@ -0,0 +1,182 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	ThumbnailSize = 200
+	MediumSize    = 800
+	// images with more pixels than this are rejected before being decoded
+	MaxPixels = 40 * 1000 * 1000
+
+	jpegQuality = 90
+)
+
+var ErrTooManyPixels = errors.New("Image has too many pixels")
+
+type Variant struct {
+	ContentType string
+	Data        []byte
+	Width       int
+	Height      int
+}
+
+// Processed holds the re-encoded upload along with its resized variants
+type Processed struct {
+	Original  *Variant
+	Medium    *Variant
+	Thumbnail *Variant
+}
+
+// Process decodes the image and re-encodes it along with a medium and a thumbnail variant.
+// Only the pixel data survives re-encoding, so EXIF, GPS and any other metadata is dropped.
+func Process(data []byte) (*Processed, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrTooManyPixels, config.Width, config.Height)
+	}
+	processed := &Processed{}
+	var img image.Image
+	if format == "gif" {
+		// decode every frame so that animations survive re-encoding
+		animation, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		processed.Original, err = encodeGIF(animation)
+		if err != nil {
+			return nil, err
+		}
+		img = animation.Image[0]
+	} else {
+		img, _, err = image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		processed.Original, err = encode(img, format)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// resized variants of animations keep only the first frame
+	resizedFormat := format
+	if format == "gif" {
+		resizedFormat = "png"
+	}
+	processed.Medium, err = encode(Fit(img, MediumSize), resizedFormat)
+	if err != nil {
+		return nil, err
+	}
+	processed.Thumbnail, err = encode(Fit(img, ThumbnailSize), resizedFormat)
+	if err != nil {
+		return nil, err
+	}
+	return processed, nil
+}
+
+func encode(img image.Image, format string) (*Variant, error) {
+	encoded := &bytes.Buffer{}
+	contentType := ""
+	switch format {
+	case "jpeg":
+		contentType = "image/jpeg"
+		if err := jpeg.Encode(encoded, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+	case "png":
+		contentType = "image/png"
+		if err := png.Encode(encoded, img); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported image format: %s", format)
+	}
+	return &Variant{
+		ContentType: contentType,
+		Data:        encoded.Bytes(),
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+	}, nil
+}
+
+func encodeGIF(animation *gif.GIF) (*Variant, error) {
+	encoded := &bytes.Buffer{}
+	if err := gif.EncodeAll(encoded, animation); err != nil {
+		return nil, err
+	}
+	return &Variant{
+		ContentType: "image/gif",
+		Data:        encoded.Bytes(),
+		Width:       animation.Config.Width,
+		Height:      animation.Config.Height,
+	}, nil
+}
+
+// Fit scales the image down so that neither side exceeds maxSize, keeping the aspect ratio.
+// Images that already fit are returned unchanged.
+func Fit(img image.Image, maxSize int) image.Image {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	if width <= maxSize && height <= maxSize {
+		return img
+	}
+	if width >= height {
+		height = max(1, height*maxSize/width)
+		width = maxSize
+	} else {
+		width = max(1, width*maxSize/height)
+		height = maxSize
+	}
+	return Resize(img, width, height)
+}
+
+// Resize scales the image to the given size by averaging the source pixels covered by
+// every destination pixel, which gives smooth results when shrinking
+func Resize(img image.Image, width int, height int) image.Image {
+	src := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(src, src.Bounds(), img, img.Bounds().Min, draw.Src)
+	srcWidth, srcHeight := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		srcY0, srcY1 := y*srcHeight/height, max((y+1)*srcHeight/height, y*srcHeight/height+1)
+		for x := 0; x < width; x++ {
+			srcX0, srcX1 := x*srcWidth/width, max((x+1)*srcWidth/width, x*srcWidth/width+1)
+			var r, g, b, a, count uint64
+			for sy := srcY0; sy < srcY1; sy++ {
+				for sx := srcX0; sx < srcX1; sx++ {
+					offset := src.PixOffset(sx, sy)
+					r += uint64(src.Pix[offset])
+					g += uint64(src.Pix[offset+1])
+					b += uint64(src.Pix[offset+2])
+					a += uint64(src.Pix[offset+3])
+					count++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / count),
+				G: uint8(g / count),
+				B: uint8(b / count),
+				A: uint8(a / count),
+			})
+		}
+	}
+	return dst
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,87 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+func newTestJPEG(t *testing.T, width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	encoded := &bytes.Buffer{}
+	if err := jpeg.Encode(encoded, img, nil); err != nil {
+		t.Fatalf("Error in encoding test image: %+v\n", err)
+	}
+	return encoded.Bytes()
+}
+
+// withExif inserts an APP1 segment carrying EXIF data right after the SOI marker
+func withExif(jpegData []byte, payload string) []byte {
+	segment := append([]byte("Exif\x00\x00"), []byte(payload)...)
+	length := len(segment) + 2
+	withSegment := []byte{0xFF, 0xD8, 0xFF, 0xE1, byte(length >> 8), byte(length)}
+	withSegment = append(withSegment, segment...)
+	return append(withSegment, jpegData[2:]...)
+}
+
+func TestProcess(t *testing.T) {
+	test_image := withExif(newTestJPEG(t, 1600, 900), "GPSLatitude=40.7128")
+	if _, _, err := image.Decode(bytes.NewReader(test_image)); err != nil {
+		t.Fatalf("Test image with EXIF can't be decoded: %+v\n", err)
+	}
+	processed, err := Process(test_image)
+	if err != nil {
+		t.Fatalf("Error in processing image: %+v\n", err)
+	}
+	for name, variant := range map[string]*Variant{
+		"original":  processed.Original,
+		"medium":    processed.Medium,
+		"thumbnail": processed.Thumbnail,
+	} {
+		if bytes.Contains(variant.Data, []byte("Exif")) || bytes.Contains(variant.Data, []byte("GPSLatitude")) {
+			t.Errorf("EXIF data not stripped from %s variant\n", name)
+		}
+		if variant.ContentType != "image/jpeg" {
+			t.Errorf("Unexpected content type of %s variant: %s\n", name, variant.ContentType)
+		}
+	}
+	if processed.Original.Width != 1600 || processed.Original.Height != 900 {
+		t.Errorf("Original resized unexpectedly: %dx%d\n", processed.Original.Width, processed.Original.Height)
+	}
+	if processed.Medium.Width != MediumSize || processed.Medium.Height != 450 {
+		t.Errorf("Unexpected medium size: %dx%d\n", processed.Medium.Width, processed.Medium.Height)
+	}
+	if processed.Thumbnail.Width != ThumbnailSize || processed.Thumbnail.Height != 112 {
+		t.Errorf("Unexpected thumbnail size: %dx%d\n", processed.Thumbnail.Width, processed.Thumbnail.Height)
+	}
+
+	processed_again, _ := Process(test_image)
+	if !bytes.Equal(processed.Thumbnail.Data, processed_again.Thumbnail.Data) {
+		t.Error("Processing the same image twice gave different thumbnails")
+	}
+}
+
+func TestFit(t *testing.T) {
+	small_image := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	if Fit(small_image, ThumbnailSize) != image.Image(small_image) {
+		t.Error("Image smaller than the limit was resized")
+	}
+	tall_image := image.NewRGBA(image.Rect(0, 0, 300, 1200))
+	fitted := Fit(tall_image, 400)
+	if fitted.Bounds().Dx() != 100 || fitted.Bounds().Dy() != 400 {
+		t.Errorf("Unexpected size of fitted image: %+v\n", fitted.Bounds())
+	}
+}
+
+func TestProcess_NotAnImage(t *testing.T) {
+	if _, err := Process([]byte("definitely not an image")); err == nil {
+		t.Error("Processing garbage didn't return an error")
+	}
+}
@@ -0,0 +1,93 @@
+package impressions
+
+import (
+	"context"
+	"sync"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+)
+
+// Service counts how many times posts were seen. Impressions are kept in memory and written to the storage
+// in batches by Flush, so that serving posts doesn't wait for the counters to be updated.
+type Service interface {
+	// Record counts an impression of each of the posts, except of the viewer's own posts
+	Record(posts []*models.Post, viewer *models.User)
+	// Flush adds the impressions recorded since the last flush to the storage, those of deleted posts are dropped
+	Flush(ctx context.Context) error
+	// GetImpressions returns the number of times each of the posts was seen, including the impressions
+	// not flushed yet
+	GetImpressions(ctx context.Context, postIds []string) (map[string]int64, error)
+	// RemovePost drops the impressions of a deleted post
+	RemovePost(ctx context.Context, post *models.Post) error
+}
+
+type ImpressionService struct {
+	db      storage.Storage
+	mtx     sync.Mutex
+	pending map[string]int64
+}
+
+func (is *ImpressionService) Record(posts []*models.Post, viewer *models.User) {
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+	for _, post := range posts {
+		if post == nil || (viewer != nil && post.PostedBy == viewer.UserName) {
+			continue
+		}
+		is.pending[post.PostID] += 1
+	}
+}
+
+func (is *ImpressionService) Flush(ctx context.Context) error {
+	is.mtx.Lock()
+	flushing := is.pending
+	is.pending = make(map[string]int64)
+	is.mtx.Unlock()
+	if len(flushing) == 0 {
+		return nil
+	}
+	done, err := is.db.ImpressionStore().AddImpressions(ctx, flushing)
+	if err != nil {
+		// keep the impressions not added yet around for the next flush, the ones added would be counted twice
+		for _, postId := range done {
+			delete(flushing, postId)
+		}
+		is.mtx.Lock()
+		for postId, count := range flushing {
+			is.pending[postId] += count
+		}
+		is.mtx.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (is *ImpressionService) GetImpressions(ctx context.Context, postIds []string) (map[string]int64, error) {
+	counts, err := is.db.ImpressionStore().GetImpressions(ctx, postIds)
+	if err != nil {
+		return nil, err
+	}
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+	for _, postId := range postIds {
+		if count := is.pending[postId]; count > 0 {
+			counts[postId] += count
+		}
+	}
+	return counts, nil
+}
+
+func (is *ImpressionService) RemovePost(ctx context.Context, post *models.Post) error {
+	is.mtx.Lock()
+	delete(is.pending, post.PostID)
+	is.mtx.Unlock()
+	return is.db.ImpressionStore().RemovePost(ctx, post.PostID)
+}
+
+func New(db storage.Storage) Service {
+	return &ImpressionService{
+		db:      db,
+		pending: make(map[string]int64),
+	}
+}
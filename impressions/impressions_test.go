@@ -0,0 +1,109 @@
+package impressions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+	"github.com/twitter/storage/memory"
+)
+
+// partialImpressions is a storage adding the impressions of the first post only, like etcd failing after
+// committing the first batch
+type partialImpressions struct {
+	storage.Storage
+}
+
+func (p partialImpressions) ImpressionStore() storage.ImpressionStore {
+	return partialImpressionStore{p.Storage.ImpressionStore()}
+}
+
+type partialImpressionStore struct {
+	storage.ImpressionStore
+}
+
+func (p partialImpressionStore) AddImpressions(ctx context.Context, counts map[string]int64) ([]string, error) {
+	done, _ := p.ImpressionStore.AddImpressions(ctx, map[string]int64{"1": counts["1"]})
+	return done, errors.New("context deadline exceeded")
+}
+
+func TestImpressionService(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	defer db.Close()
+	test_service := New(db)
+	author := &models.User{UserName: "author"}
+	viewer := &models.User{UserName: "viewer"}
+	test_posts := []*models.Post{{PostedBy: "author"}, {PostedBy: "author"}}
+	for _, post := range test_posts {
+		db.PostStore().CreatePost(ctx, post)
+	}
+
+	test_service.Record(test_posts, viewer)
+	test_service.Record(test_posts[:1], nil)
+	test_service.Record(test_posts, author)
+	counts, err := test_service.GetImpressions(ctx, []string{"1", "2", "3"})
+	if err != nil {
+		t.Errorf("Error in getting impressions: %+v\n", err)
+	}
+	if counts["1"] != 2 || counts["2"] != 1 || len(counts) != 2 {
+		t.Errorf("Unexpected impressions before flushing: %+v\n", counts)
+	}
+
+	if err := test_service.Flush(ctx); err != nil {
+		t.Errorf("Error in flushing impressions: %+v\n", err)
+	}
+	stored, _ := db.ImpressionStore().GetImpressions(ctx, []string{"1", "2"})
+	if stored["1"] != 2 || stored["2"] != 1 {
+		t.Errorf("Impressions not written to the storage: %+v\n", stored)
+	}
+	test_service.Record(test_posts[:1], viewer)
+	counts, _ = test_service.GetImpressions(ctx, []string{"1"})
+	if counts["1"] != 3 {
+		t.Errorf("Impressions counted twice or lost after flushing: %+v\n", counts)
+	}
+
+	test_service.RemovePost(ctx, test_posts[0])
+	db.PostStore().DeletePost(ctx, test_posts[0])
+	test_service.Flush(ctx)
+	counts, _ = test_service.GetImpressions(ctx, []string{"1", "2"})
+	if counts["1"] != 0 || counts["2"] != 1 {
+		t.Errorf("Impressions of a removed post still counted: %+v\n", counts)
+	}
+}
+
+func TestImpressionService_Flush(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	defer db.Close()
+	test_service := New(partialImpressions{db})
+	viewer := &models.User{UserName: "viewer"}
+	test_posts := []*models.Post{{PostedBy: "author"}, {PostedBy: "author"}, {PostedBy: "author"}}
+	for _, post := range test_posts {
+		db.PostStore().CreatePost(ctx, post)
+	}
+	db.PostStore().DeletePost(ctx, test_posts[2])
+
+	test_service.Record(test_posts, viewer)
+	if err := test_service.Flush(ctx); err == nil {
+		t.Error("Failure of the storage not returned")
+	}
+	counts, _ := test_service.GetImpressions(ctx, []string{"1", "2"})
+	if counts["1"] != 1 || counts["2"] != 1 {
+		t.Errorf("Impressions added before the failure requeued or others lost: %+v\n", counts)
+	}
+
+	test_service = New(db)
+	test_service.Record(test_posts, viewer)
+	if err := test_service.Flush(ctx); err != nil {
+		t.Errorf("Error in flushing impressions: %+v\n", err)
+	}
+	if pending := test_service.(*ImpressionService).pending; len(pending) != 0 {
+		t.Errorf("Impressions of a deleted post kept pending: %+v\n", pending)
+	}
+	if stored, _ := db.ImpressionStore().GetImpressions(ctx, []string{"3"}); len(stored) != 0 {
+		t.Errorf("Impressions of a deleted post stored: %+v\n", stored)
+	}
+}
@@ -0,0 +1,178 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/twitter/models"
+	"github.com/twitter/posts"
+	"github.com/twitter/storage"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	MaxNameLength = 50
+	MaxMembers    = 500
+	MaxLists      = 100
+)
+
+var (
+	ErrInvalidName    = errors.New("List name has to be between 1 and 50 characters")
+	ErrTooManyMembers = errors.New("List can't have more than 500 members")
+	ErrTooManyLists   = errors.New("User can't own more than 100 lists")
+	ErrNotFound       = errors.New("List not found")
+	ErrNotOwner       = errors.New("Only the owner can change a list")
+)
+
+// Service manages the named lists of accounts users curate, and builds their timelines
+type Service interface {
+	CreateList(ctx context.Context, owner *models.User, name string, private bool) (*models.UserList, error)
+	// GetList returns the list if the viewer is allowed to see it, private lists are only visible to their owner
+	GetList(ctx context.Context, viewer *models.User, listId string) (*models.UserList, error)
+	UpdateList(ctx context.Context, owner *models.User, list *models.UserList) (*models.UserList, error)
+	DeleteList(ctx context.Context, owner *models.User, listId string) error
+	// GetUserLists returns the lists of the user the viewer is allowed to see
+	GetUserLists(ctx context.Context, viewer *models.User, owner string) ([]*models.UserList, error)
+	AddMember(ctx context.Context, owner *models.User, listId string, member *models.User) (*models.UserList, error)
+	RemoveMember(ctx context.Context, owner *models.User, listId string, userName string) (*models.UserList, error)
+	// GetTimeline returns the posts of the list's members, built like the home feed
+	GetTimeline(ctx context.Context, viewer *models.User, listId string) ([]*models.Post, error)
+}
+
+type ListService struct {
+	db          storage.Storage
+	postService posts.Service
+}
+
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > MaxNameLength {
+		return "", ErrInvalidName
+	}
+	return name, nil
+}
+
+func canView(viewer *models.User, list *models.UserList) bool {
+	return !list.Private || (viewer != nil && viewer.UserName == list.Owner)
+}
+
+func (ls *ListService) CreateList(ctx context.Context, owner *models.User, name string, private bool) (*models.UserList, error) {
+	name, err := validateName(name)
+	if err != nil {
+		return nil, err
+	}
+	ownedLists, err := ls.db.ListStore().GetLists(ctx, owner.UserName)
+	if err != nil {
+		return nil, err
+	}
+	if len(ownedLists) >= MaxLists {
+		return nil, ErrTooManyLists
+	}
+	return ls.db.ListStore().CreateList(ctx, &models.UserList{
+		Owner:     owner.UserName,
+		Name:      name,
+		Private:   private,
+		CreatedAt: timestamppb.Now(),
+	})
+}
+
+func (ls *ListService) GetList(ctx context.Context, viewer *models.User, listId string) (*models.UserList, error) {
+	list, err := ls.db.ListStore().GetList(ctx, listId)
+	if err != nil || !canView(viewer, list) {
+		// private lists are reported as missing so that their existence isn't revealed
+		return nil, ErrNotFound
+	}
+	return list, nil
+}
+
+// getOwnedList returns the list if the user owns it
+func (ls *ListService) getOwnedList(ctx context.Context, owner *models.User, listId string) (*models.UserList, error) {
+	list, err := ls.GetList(ctx, owner, listId)
+	if err != nil {
+		return nil, err
+	}
+	if list.Owner != owner.UserName {
+		return nil, ErrNotOwner
+	}
+	return list, nil
+}
+
+func (ls *ListService) UpdateList(ctx context.Context, owner *models.User, list *models.UserList) (*models.UserList, error) {
+	if _, err := ls.getOwnedList(ctx, owner, list.ListID); err != nil {
+		return nil, err
+	}
+	name, err := validateName(list.Name)
+	if err != nil {
+		return nil, err
+	}
+	return ls.db.ListStore().UpdateList(ctx, &models.UserList{
+		ListID:  list.ListID,
+		Name:    name,
+		Private: list.Private,
+	})
+}
+
+func (ls *ListService) DeleteList(ctx context.Context, owner *models.User, listId string) error {
+	if _, err := ls.getOwnedList(ctx, owner, listId); err != nil {
+		return err
+	}
+	return ls.db.ListStore().DeleteList(ctx, listId)
+}
+
+func (ls *ListService) GetUserLists(ctx context.Context, viewer *models.User, owner string) ([]*models.UserList, error) {
+	ownedLists, err := ls.db.ListStore().GetLists(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	visibleLists := make([]*models.UserList, 0, len(ownedLists))
+	for _, list := range ownedLists {
+		if canView(viewer, list) {
+			visibleLists = append(visibleLists, list)
+		}
+	}
+	return visibleLists, nil
+}
+
+func (ls *ListService) AddMember(ctx context.Context, owner *models.User, listId string, member *models.User) (*models.UserList, error) {
+	list, err := ls.getOwnedList(ctx, owner, listId)
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Members) >= MaxMembers {
+		return nil, ErrTooManyMembers
+	}
+	if err := ls.db.ListStore().AddMember(ctx, listId, member.UserName); err != nil {
+		return nil, err
+	}
+	return ls.db.ListStore().GetList(ctx, listId)
+}
+
+func (ls *ListService) RemoveMember(ctx context.Context, owner *models.User, listId string, userName string) (*models.UserList, error) {
+	if _, err := ls.getOwnedList(ctx, owner, listId); err != nil {
+		return nil, err
+	}
+	if err := ls.db.ListStore().RemoveMember(ctx, listId, userName); err != nil {
+		return nil, err
+	}
+	return ls.db.ListStore().GetList(ctx, listId)
+}
+
+func (ls *ListService) GetTimeline(ctx context.Context, viewer *models.User, listId string) ([]*models.Post, error) {
+	list, err := ls.GetList(ctx, viewer, listId)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*models.User, 0, len(list.Members))
+	for _, member := range list.Members {
+		members = append(members, &models.User{UserName: member})
+	}
+	return ls.postService.GetFeed(ctx, members)
+}
+
+func New(db storage.Storage, postService posts.Service) Service {
+	return &ListService{
+		db:          db,
+		postService: postService,
+	}
+}
@@ -0,0 +1,74 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/posts"
+	"github.com/twitter/storage/memory"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListService(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	defer db.Close()
+	test_service := New(db, posts.New(db, time.Minute, posts.NewDecayScorer(time.Hour)))
+	owner := &models.User{UserName: "owner"}
+	other := &models.User{UserName: "other"}
+	db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "member", Content: "in the list", PostedAt: timestamppb.Now()})
+	db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "outsider", Content: "not in the list", PostedAt: timestamppb.Now()})
+
+	if _, err := test_service.CreateList(ctx, owner, "  ", false); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("Able to create a list without a name: %+v\n", err)
+	}
+	private_list, err := test_service.CreateList(ctx, owner, "close friends", true)
+	if err != nil {
+		t.Errorf("Error in creating list: %+v\n", err)
+	}
+	if _, err := test_service.AddMember(ctx, other, private_list.ListID, &models.User{UserName: "member"}); err == nil {
+		t.Error("Able to add a member to someone else's list")
+	}
+	updated_list, err := test_service.AddMember(ctx, owner, private_list.ListID, &models.User{UserName: "member"})
+	if err != nil || len(updated_list.Members) != 1 || updated_list.Members[0] != "member" {
+		t.Errorf("Member not added: %+v %+v\n", updated_list, err)
+	}
+
+	timeline, err := test_service.GetTimeline(ctx, owner, private_list.ListID)
+	if err != nil || len(timeline) != 1 || timeline[0].PostedBy != "member" {
+		t.Errorf("Unexpected list timeline: %+v %+v\n", timeline, err)
+	}
+	if _, err := test_service.GetTimeline(ctx, other, private_list.ListID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Private list timeline visible to another user: %+v\n", err)
+	}
+	other_view, _ := test_service.GetUserLists(ctx, other, owner.UserName)
+	if len(other_view) != 0 {
+		t.Errorf("Private list listed for another user: %+v\n", other_view)
+	}
+
+	private_list.Private = false
+	if _, err := test_service.UpdateList(ctx, owner, private_list); err != nil {
+		t.Errorf("Error in updating list: %+v\n", err)
+	}
+	other_view, _ = test_service.GetUserLists(ctx, other, owner.UserName)
+	if len(other_view) != 1 {
+		t.Errorf("Public list not listed for another user: %+v\n", other_view)
+	}
+
+	updated_list, _ = test_service.RemoveMember(ctx, owner, private_list.ListID, "member")
+	if len(updated_list.Members) != 0 {
+		t.Errorf("Member not removed: %+v\n", updated_list)
+	}
+	if err := test_service.DeleteList(ctx, other, private_list.ListID); !errors.Is(err, ErrNotOwner) {
+		t.Errorf("Able to delete someone else's list: %+v\n", err)
+	}
+	if err := test_service.DeleteList(ctx, owner, private_list.ListID); err != nil {
+		t.Errorf("Error in deleting list: %+v\n", err)
+	}
+	if _, err := test_service.GetList(ctx, owner, private_list.ListID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Deleted list still present: %+v\n", err)
+	}
+}
@@ -0,0 +1,152 @@
+// Package logging sets up structured logging and tags the logs of every request with its request id
+package logging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDKey is the gRPC metadata key, and HTTP header, carrying the id of the request
+const RequestIDKey = "x-request-id"
+
+var ErrUnknownFormat = errors.New("Unrecognized log format")
+
+type requestIDKey struct{}
+
+type loggerKey struct{}
+
+// New builds a logger writing records of at least the given level ("debug", "info", "warn" or "error")
+// as "text" or "json"
+func New(w io.Writer, level string, format string) (*slog.Logger, error) {
+	var minLevel slog.Level
+	if err := minLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, err
+	}
+	options := &slog.HandlerOptions{Level: minLevel}
+	switch format {
+	case "text", "":
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// WithRequestID returns a copy of ctx carrying the request id, along with a logger tagging its records with it
+func WithRequestID(ctx context.Context, logger *slog.Logger, requestId string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, requestId)
+	return context.WithValue(ctx, loggerKey{}, logger.With("request_id", requestId))
+}
+
+// RequestID returns the id of the request ctx belongs to, empty if there is none
+func RequestID(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIDKey{}).(string)
+	return requestId
+}
+
+// FromContext returns the logger of the request ctx belongs to, the default logger outside of requests
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// incomingRequestID returns the request id the client sent, or a new one if it didn't send any
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	return uuid.New().String()
+}
+
+// levelOf logs failures that aren't the client's fault as errors
+func levelOf(code codes.Code) slog.Level {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func logRPC(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	args := []any{"method", method, "code", code.String(), "duration", time.Since(start)}
+	if err != nil {
+		args = append(args, "err", err)
+	}
+	FromContext(ctx).Log(ctx, levelOf(code), "Handled RPC", args...)
+}
+
+// UnaryServerInterceptor tags the context of every RPC with the request id found in its metadata and logs the
+// outcome of the RPC
+func UnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = WithRequestID(ctx, logger, incomingRequestID(ctx))
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logRPC(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// requestStream replaces the context of the stream with the one carrying the request id
+type requestStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor does the same as UnaryServerInterceptor for streaming RPCs
+func StreamServerInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := WithRequestID(stream.Context(), logger, incomingRequestID(stream.Context()))
+		start := time.Now()
+		err := handler(srv, &requestStream{stream, ctx})
+		logRPC(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// statusRecorder remembers the status code written to the response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Handler gives every request a new request id, sent along as gRPC metadata by the calls made with the context
+// of the request and returned in the response header, and logs the outcome of the request
+func Handler(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := uuid.New().String()
+		ctx := WithRequestID(r.Context(), logger, requestId)
+		ctx = metadata.AppendToOutgoingContext(ctx, RequestIDKey, requestId)
+		w.Header().Set(RequestIDKey, requestId)
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+		FromContext(ctx).Info("Handled request", "method", r.Method, "path", r.URL.Path, "status", recorder.status, "duration", time.Since(start))
+	})
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, "loud", "text"); err == nil {
+		t.Error("Unrecognized log level accepted")
+	}
+	if _, err := New(&bytes.Buffer{}, "info", "xml"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Unrecognized log format accepted: %+v\n", err)
+	}
+
+	var output bytes.Buffer
+	logger, err := New(&output, "warn", "json")
+	if err != nil {
+		t.Fatalf("Error in creating the logger: %+v\n", err)
+	}
+	logger.Info("below the level")
+	logger.Warn("at the level")
+	if strings.Contains(output.String(), "below the level") || !strings.Contains(output.String(), `"msg":"at the level"`) {
+		t.Errorf("Log level not applied: %s\n", output.String())
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	var output bytes.Buffer
+	logger, _ := New(&output, "info", "text")
+	interceptor := UnaryServerInterceptor(logger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/twitter.Twitter/GetPost"}
+
+	var seen_id string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen_id = RequestID(ctx)
+		FromContext(ctx).Info("handling")
+		return nil, nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "web-id"))
+	interceptor(ctx, nil, info, handler)
+	if seen_id != "web-id" {
+		t.Errorf("Request id of the metadata not used: %+v\n", seen_id)
+	}
+	if strings.Count(output.String(), "request_id=web-id") != 2 {
+		t.Errorf("Logs not tagged with the request id: %s\n", output.String())
+	}
+
+	interceptor(context.Background(), nil, info, handler)
+	if seen_id == "" || seen_id == "web-id" {
+		t.Errorf("No new request id generated for a request without one: %+v\n", seen_id)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var output bytes.Buffer
+	logger, _ := New(&output, "info", "text")
+	var sent_ids []string
+	handler := Handler(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		md, _ := metadata.FromOutgoingContext(r.Context())
+		sent_ids = md.Get(RequestIDKey)
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/home", nil))
+	request_id := recorder.Header().Get(RequestIDKey)
+	if request_id == "" || len(sent_ids) != 1 || sent_ids[0] != request_id {
+		t.Errorf("Request id not passed on to gRPC: %+v %+v\n", request_id, sent_ids)
+	}
+	if !strings.Contains(output.String(), "request_id="+request_id) || !strings.Contains(output.String(), "status=418") {
+		t.Errorf("Request not logged: %s\n", output.String())
+	}
+}
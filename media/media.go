@@ -0,0 +1,137 @@
+package media
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/twitter/blobstore"
+	"github.com/twitter/imaging"
+	"github.com/twitter/models"
+)
+
+const (
+	// URLPrefix is the path the web frontend serves stored media from
+	URLPrefix = "/media/"
+	// ChunkSize is the size of the chunks media is streamed in
+	ChunkSize = 64 * 1024
+)
+
+var (
+	ErrUnsupportedType = errors.New("Unsupported media type")
+	ErrTooLarge        = errors.New("Media is too large")
+)
+
+// only formats the standard image packages can decode are accepted, since every
+// upload is decoded and re-encoded to strip its metadata
+var allowedContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type Service interface {
+	// Upload validates the media, strips its metadata and stores it along with its resized variants
+	Upload(contentType string, data []byte) (*models.Media, error)
+	// Open returns a reader for the stored media, the caller has to close it
+	Open(mediaId string) (io.ReadCloser, *models.Media, error)
+	// MaxSize is the largest upload in bytes that will be accepted
+	MaxSize() int64
+}
+
+type MediaService struct {
+	store   blobstore.Store
+	maxSize int64
+}
+
+func (ms *MediaService) MaxSize() int64 {
+	return ms.maxSize
+}
+
+// validate makes sure the declared content type is allowed and matches the actual content
+func (ms *MediaService) validate(contentType string, data []byte) error {
+	if int64(len(data)) > ms.maxSize {
+		return ErrTooLarge
+	}
+	if !allowedContentTypes[contentType] {
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+	if sniffedType := http.DetectContentType(data); sniffedType != contentType {
+		return fmt.Errorf("%w: declared %s but content is %s", ErrUnsupportedType, contentType, sniffedType)
+	}
+	return nil
+}
+
+// storeVariant saves the variant under the hash of its content, so identical variants are only stored once
+func (ms *MediaService) storeVariant(variant *imaging.Variant) (string, error) {
+	contentHash := sha256.Sum256(variant.Data)
+	key := hex.EncodeToString(contentHash[:])
+	_, err := ms.store.Stat(key)
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, blobstore.ErrNotFound) {
+		return "", err
+	}
+	if _, err := ms.store.Put(key, variant.ContentType, bytes.NewReader(variant.Data)); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (ms *MediaService) Upload(contentType string, data []byte) (*models.Media, error) {
+	if err := ms.validate(contentType, data); err != nil {
+		return nil, err
+	}
+	processed, err := imaging.Process(data)
+	if errors.Is(err, imaging.ErrTooManyPixels) {
+		return nil, fmt.Errorf("%w: %v", ErrTooLarge, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+	mediaId, err := ms.storeVariant(processed.Original)
+	if err != nil {
+		return nil, err
+	}
+	mediumId, err := ms.storeVariant(processed.Medium)
+	if err != nil {
+		return nil, err
+	}
+	thumbnailId, err := ms.storeVariant(processed.Thumbnail)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Media{
+		MediaID:      mediaId,
+		URL:          URLPrefix + mediaId,
+		ContentType:  processed.Original.ContentType,
+		Size:         int64(len(processed.Original.Data)),
+		MediumURL:    URLPrefix + mediumId,
+		ThumbnailURL: URLPrefix + thumbnailId,
+	}, nil
+}
+
+func (ms *MediaService) Open(mediaId string) (io.ReadCloser, *models.Media, error) {
+	reader, blobInfo, err := ms.store.Get(mediaId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reader, &models.Media{
+		MediaID:     mediaId,
+		URL:         URLPrefix + mediaId,
+		ContentType: blobInfo.ContentType,
+		Size:        blobInfo.Size,
+	}, nil
+}
+
+func New(store blobstore.Store, maxSize int64) Service {
+	return &MediaService{
+		store:   store,
+		maxSize: maxSize,
+	}
+}
@@ -0,0 +1,102 @@
+// Package metrics reports what the twitter server is doing to prometheus
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twitter/logging"
+	"github.com/twitter/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCMetrics counts the RPCs handled by the server along with their status codes and latency
+type GRPCMetrics struct {
+	handled  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func NewGRPCMetrics(registerer prometheus.Registerer) (*GRPCMetrics, error) {
+	grpcMetrics := &GRPCMetrics{
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "twitter",
+			Subsystem: "grpc",
+			Name:      "handled_total",
+			Help:      "Number of RPCs handled, by method and status code.",
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "twitter",
+			Subsystem: "grpc",
+			Name:      "handling_seconds",
+			Help:      "Latency of handling RPCs, by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+	}
+	if err := registerer.Register(grpcMetrics.handled); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(grpcMetrics.duration); err != nil {
+		return nil, err
+	}
+	return grpcMetrics, nil
+}
+
+func (m *GRPCMetrics) observe(method string, start time.Time, err error) {
+	m.duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	m.handled.WithLabelValues(method, status.Code(err).String()).Inc()
+}
+
+func (m *GRPCMetrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observe(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func (m *GRPCMetrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		m.observe(info.FullMethod, start, err)
+		return err
+	}
+}
+
+// contentCollector reports the number of users and posts in the storage whenever it is scraped
+type contentCollector struct {
+	db    storage.Storage
+	users *prometheus.Desc
+	posts *prometheus.Desc
+}
+
+func (c *contentCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- c.users
+	descs <- c.posts
+}
+
+func (c *contentCollector) Collect(metrics chan<- prometheus.Metric) {
+	ctx := context.Background()
+	if users, err := c.db.UserStore().CountUsers(ctx); err != nil {
+		logging.FromContext(ctx).Error("Unable to count users", "err", err)
+	} else {
+		metrics <- prometheus.MustNewConstMetric(c.users, prometheus.GaugeValue, float64(users))
+	}
+	if posts, err := c.db.PostStore().CountPosts(ctx); err != nil {
+		logging.FromContext(ctx).Error("Unable to count posts", "err", err)
+	} else {
+		metrics <- prometheus.MustNewConstMetric(c.posts, prometheus.GaugeValue, float64(posts))
+	}
+}
+
+// RegisterContentCounts reports the number of users and posts in the storage to the registerer
+func RegisterContentCounts(registerer prometheus.Registerer, db storage.Storage) error {
+	return registerer.Register(&contentCollector{
+		db:    db,
+		users: prometheus.NewDesc("twitter_users", "Number of registered users.", nil, nil),
+		posts: prometheus.NewDesc("twitter_posts", "Number of posts.", nil, nil),
+	})
+}
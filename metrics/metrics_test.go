@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/twitter/models"
+	"github.com/twitter/storage/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCMetrics_UnaryServerInterceptor(t *testing.T) {
+	test_metrics, err := NewGRPCMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("Error in registering metrics: %+v\n", err)
+	}
+	interceptor := test_metrics.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/twitter.Twitter/GetPost"}
+	ok_handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+	failing_handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+	interceptor(context.Background(), "request", info, ok_handler)
+	interceptor(context.Background(), "request", info, ok_handler)
+	if _, err := interceptor(context.Background(), "request", info, failing_handler); status.Code(err) != codes.NotFound {
+		t.Errorf("Interceptor changed the error of the handler: %+v\n", err)
+	}
+
+	if handled := testutil.ToFloat64(test_metrics.handled.WithLabelValues(info.FullMethod, "OK")); handled != 2 {
+		t.Errorf("Unexpected number of successful RPCs: %+v\n", handled)
+	}
+	if handled := testutil.ToFloat64(test_metrics.handled.WithLabelValues(info.FullMethod, "NotFound")); handled != 1 {
+		t.Errorf("Unexpected number of failed RPCs: %+v\n", handled)
+	}
+	if count := testutil.CollectAndCount(test_metrics.duration); count != 1 {
+		t.Errorf("Unexpected number of latency series: %+v\n", count)
+	}
+}
+
+func TestRegisterContentCounts(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	defer db.Close()
+	db.UserStore().AddUser(ctx, &models.User{UserName: "test1"})
+	db.UserStore().AddUser(ctx, &models.User{UserName: "test2"})
+	db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "test1"})
+	registry := prometheus.NewRegistry()
+	if err := RegisterContentCounts(registry, db); err != nil {
+		t.Fatalf("Error in registering content counts: %+v\n", err)
+	}
+	expected := `
+# HELP twitter_posts Number of posts.
+# TYPE twitter_posts gauge
+twitter_posts 1
+# HELP twitter_users Number of registered users.
+# TYPE twitter_users gauge
+twitter_users 2
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected)); err != nil {
+		t.Errorf("Unexpected content counts: %+v\n", err)
+	}
+}
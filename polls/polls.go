@@ -0,0 +1,130 @@
+package polls
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	MinOptions = 2
+	MaxOptions = 4
+	// the longest a poll can stay open
+	MaxDuration = 7 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidPoll   = errors.New("Poll needs 2 to 4 non-empty options and a closing time within 7 days")
+	ErrNoPoll        = errors.New("Post has no poll")
+	ErrPollClosed    = errors.New("Poll is closed")
+	ErrInvalidOption = errors.New("Poll has no such option")
+)
+
+type Service interface {
+	Vote(ctx context.Context, post *models.Post, user *models.User, option int) (*models.Poll, error)
+	// WithResults returns copies of the posts whose polls carry the state seen by the viewer.
+	// Tallies are only included once the viewer voted or the poll closed.
+	WithResults(ctx context.Context, posts []*models.Post, viewer *models.User) ([]*models.Post, error)
+	RemovePoll(ctx context.Context, post *models.Post) error
+}
+
+type PollService struct {
+	db storage.Storage
+}
+
+// Validate checks the poll of a new post and strips any state a client may have sent along
+func Validate(poll *models.Poll, now time.Time) error {
+	if len(poll.Options) < MinOptions || len(poll.Options) > MaxOptions || poll.ClosesAt == nil {
+		return ErrInvalidPoll
+	}
+	closesAt := poll.ClosesAt.AsTime()
+	if !closesAt.After(now) || closesAt.Sub(now) > MaxDuration {
+		return ErrInvalidPoll
+	}
+	for _, option := range poll.Options {
+		option.Text = strings.TrimSpace(option.Text)
+		if option.Text == "" {
+			return ErrInvalidPoll
+		}
+		option.Votes = 0
+	}
+	poll.Closed = false
+	poll.HasVoted = false
+	poll.VotedOption = 0
+	poll.ResultsVisible = false
+	poll.TotalVotes = 0
+	return nil
+}
+
+func (ps *PollService) Vote(ctx context.Context, post *models.Post, user *models.User, option int) (*models.Poll, error) {
+	if post.Poll == nil {
+		return nil, ErrNoPoll
+	}
+	if !time.Now().Before(post.Poll.ClosesAt.AsTime()) {
+		return nil, ErrPollClosed
+	}
+	if option < 0 || option >= len(post.Poll.Options) {
+		return nil, ErrInvalidOption
+	}
+	if err := ps.db.PollStore().AddVote(ctx, post.PostID, user.UserName, option); err != nil {
+		return nil, err
+	}
+	withResults, err := ps.WithResults(ctx, []*models.Post{post}, user)
+	if err != nil {
+		return nil, err
+	}
+	return withResults[0].Poll, nil
+}
+
+func (ps *PollService) WithResults(ctx context.Context, posts []*models.Post, viewer *models.User) ([]*models.Post, error) {
+	withResults := make([]*models.Post, 0, len(posts))
+	for _, post := range posts {
+		if post == nil || post.Poll == nil {
+			withResults = append(withResults, post)
+			continue
+		}
+		// the storage may hand out the post it keeps, so the copy is filled in instead
+		post = proto.Clone(post).(*models.Post)
+		poll := post.Poll
+		poll.Closed = !time.Now().Before(poll.ClosesAt.AsTime())
+		if viewer != nil && viewer.UserName != "" {
+			option, voted, err := ps.db.PollStore().GetVote(ctx, post.PostID, viewer.UserName)
+			if err != nil {
+				return nil, err
+			}
+			poll.HasVoted = voted
+			poll.VotedOption = int32(option)
+		}
+		poll.ResultsVisible = poll.HasVoted || poll.Closed
+		if poll.ResultsVisible {
+			tallies, err := ps.db.PollStore().GetTallies(ctx, post.PostID, len(poll.Options))
+			if err != nil {
+				return nil, err
+			}
+			for idx, option := range poll.Options {
+				option.Votes = tallies[idx]
+				poll.TotalVotes += tallies[idx]
+			}
+		}
+		withResults = append(withResults, post)
+	}
+	return withResults, nil
+}
+
+func (ps *PollService) RemovePoll(ctx context.Context, post *models.Post) error {
+	if post.Poll == nil {
+		return nil
+	}
+	return ps.db.PollStore().RemovePoll(ctx, post.PostID)
+}
+
+func New(db storage.Storage) Service {
+	return &PollService{
+		db: db,
+	}
+}
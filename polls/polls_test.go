@@ -0,0 +1,96 @@
+package polls
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+	"github.com/twitter/storage/memory"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newPoll(closesAt time.Time, options ...string) *models.Poll {
+	poll := &models.Poll{ClosesAt: timestamppb.New(closesAt)}
+	for _, option := range options {
+		poll.Options = append(poll.Options, &models.PollOption{Text: option})
+	}
+	return poll
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		poll    *models.Poll
+		wantErr bool
+	}{
+		{"two options", newPoll(now.Add(time.Hour), "yes", "no"), false},
+		{"four options", newPoll(now.Add(time.Hour), "a", "b", "c", "d"), false},
+		{"one option", newPoll(now.Add(time.Hour), "yes"), true},
+		{"five options", newPoll(now.Add(time.Hour), "a", "b", "c", "d", "e"), true},
+		{"empty option", newPoll(now.Add(time.Hour), "yes", "  "), true},
+		{"already closed", newPoll(now.Add(-time.Hour), "yes", "no"), true},
+		{"open too long", newPoll(now.Add(MaxDuration+time.Hour), "yes", "no"), true},
+		{"no closing time", &models.Poll{Options: []*models.PollOption{{Text: "yes"}, {Text: "no"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.poll, now); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPollService_Vote(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	defer db.Close()
+	test_service := New(db)
+	test_post, _ := db.PostStore().CreatePost(ctx, &models.Post{
+		PostedBy: "author",
+		Content:  "pick one",
+		Poll:     newPoll(time.Now().Add(time.Hour), "yes", "no"),
+	})
+	voter := &models.User{UserName: "voter"}
+	viewer := &models.User{UserName: "viewer"}
+
+	withResults, _ := test_service.WithResults(ctx, []*models.Post{test_post}, voter)
+	if withResults[0].Poll.ResultsVisible || withResults[0].Poll.Options[0].Votes != 0 {
+		t.Errorf("Results visible before voting: %+v\n", withResults[0].Poll)
+	}
+
+	poll, err := test_service.Vote(ctx, test_post, voter, 1)
+	if err != nil {
+		t.Errorf("Error in voting: %+v\n", err)
+	}
+	if !poll.ResultsVisible || !poll.HasVoted || poll.VotedOption != 1 || poll.Options[1].Votes != 1 || poll.TotalVotes != 1 {
+		t.Errorf("Unexpected poll after voting: %+v\n", poll)
+	}
+	if test_post.Poll.ResultsVisible {
+		t.Error("Voting changed the stored post")
+	}
+	if _, err := test_service.Vote(ctx, test_post, voter, 0); !errors.Is(err, storage.ErrAlreadyVoted) {
+		t.Errorf("Able to vote twice: %+v\n", err)
+	}
+	if _, err := test_service.Vote(ctx, test_post, viewer, 2); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Able to vote for a missing option: %+v\n", err)
+	}
+
+	withResults, _ = test_service.WithResults(ctx, []*models.Post{test_post}, viewer)
+	if withResults[0].Poll.ResultsVisible || withResults[0].Poll.TotalVotes != 0 {
+		t.Errorf("Results visible to a viewer who didn't vote: %+v\n", withResults[0].Poll)
+	}
+
+	test_post.Poll.ClosesAt = timestamppb.New(time.Now().Add(-time.Minute))
+	if _, err := test_service.Vote(ctx, test_post, viewer, 0); !errors.Is(err, ErrPollClosed) {
+		t.Errorf("Able to vote in a closed poll: %+v\n", err)
+	}
+	withResults, _ = test_service.WithResults(ctx, []*models.Post{test_post}, viewer)
+	if !withResults[0].Poll.Closed || !withResults[0].Poll.ResultsVisible || withResults[0].Poll.TotalVotes != 1 {
+		t.Errorf("Results hidden after the poll closed: %+v\n", withResults[0].Poll)
+	}
+}
@@ -0,0 +1,115 @@
+package posts
+
+import (
+	"context"
+	"errors"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+	"google.golang.org/protobuf/proto"
+)
+
+// FeedAuthors returns the users whose posts make up the viewer's feed
+func FeedAuthors(viewer *models.User, options *models.FeedOptions) []*models.User {
+	authors := make([]*models.User, 0, len(viewer.Follows)+1)
+	for _, following := range viewer.Follows {
+		if following != viewer.UserName {
+			authors = append(authors, &models.User{UserName: following})
+		}
+	}
+	if options.GetIncludeOwnPosts() {
+		authors = append(authors, &models.User{UserName: viewer.UserName})
+	}
+	return authors
+}
+
+// FilterFeed drops the reposts and replies the options leave out of the viewer's feed
+func FilterFeed(viewer *models.User, feed []*models.Post, options *models.FeedOptions) []*models.Post {
+	following := make(map[string]struct{}, len(viewer.Follows))
+	for _, userName := range viewer.Follows {
+		following[userName] = struct{}{}
+	}
+	filtered := make([]*models.Post, 0, len(feed))
+	for _, post := range feed {
+		if post.RepostOf != "" && !options.GetIncludeReposts() {
+			continue
+		}
+		// the viewer always sees their own replies along with their own posts
+		if post.ReplyTo != "" && post.PostedBy != viewer.UserName {
+			switch options.GetReplies() {
+			case models.ReplyPolicy_REPLIES_NONE:
+				continue
+			case models.ReplyPolicy_REPLIES_FOLLOWING:
+				if _, follows := following[post.ReplyToUser]; !follows && post.ReplyToUser != viewer.UserName {
+					continue
+				}
+			}
+		}
+		filtered = append(filtered, post)
+	}
+	return filtered
+}
+
+// WithoutBlocked drops the posts written, reposted or replied to by the users the viewer blocked. The reposts have
+// to be resolved first so that the author of the reposted post is known.
+func WithoutBlocked(feed []*models.Post, blocked []string) []*models.Post {
+	if len(blocked) == 0 {
+		return feed
+	}
+	blockedUsers := make(map[string]struct{}, len(blocked))
+	for _, userName := range blocked {
+		blockedUsers[userName] = struct{}{}
+	}
+	isBlocked := func(userName string) bool {
+		_, ok := blockedUsers[userName]
+		return ok
+	}
+	filtered := make([]*models.Post, 0, len(feed))
+	for _, post := range feed {
+		if isBlocked(post.PostedBy) || isBlocked(post.RepostedBy) || isBlocked(post.ReplyToUser) {
+			continue
+		}
+		filtered = append(filtered, post)
+	}
+	return filtered
+}
+
+func (ps *PostService) ResolveReposts(ctx context.Context, posts []*models.Post) ([]*models.Post, error) {
+	resolved := make([]*models.Post, 0, len(posts))
+	seen := make(map[string]struct{}, len(posts))
+	for _, post := range posts {
+		if post.RepostOf != "" {
+			original, err := ps.db.PostStore().GetPost(ctx, post.RepostOf)
+			if errors.Is(err, storage.ErrPostNotFound) {
+				// the reposted post was deleted or expired, the repost goes along with it
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			// the storage may hand out the post it keeps, so the copy is marked instead
+			original = proto.Clone(original).(*models.Post)
+			original.RepostedBy = post.PostedBy
+			post = original
+		}
+		if _, duplicate := seen[post.PostID]; duplicate {
+			continue
+		}
+		seen[post.PostID] = struct{}{}
+		resolved = append(resolved, post)
+	}
+	return resolved, nil
+}
+
+func (ps *PostService) GetRepost(ctx context.Context, user *models.User, postId string) (*models.Post, error) {
+	userPosts, err := ps.db.PostStore().GetPosts(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	for _, post := range userPosts {
+		if post.RepostOf == postId {
+			return post, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,132 @@
+package posts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+	"github.com/twitter/storage/memory"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFeedAuthors(t *testing.T) {
+	viewer := &models.User{UserName: "viewer", Follows: []string{"author1", "author2"}}
+	authors := FeedAuthors(viewer, &models.FeedOptions{})
+	if len(authors) != 2 {
+		t.Errorf("Unexpected authors without own posts: %+v\n", authors)
+	}
+	authors = FeedAuthors(viewer, &models.FeedOptions{IncludeOwnPosts: true})
+	if len(authors) != 3 || authors[2].UserName != "viewer" {
+		t.Errorf("Viewer missing from the authors: %+v\n", authors)
+	}
+}
+
+func TestFilterFeed(t *testing.T) {
+	viewer := &models.User{UserName: "viewer", Follows: []string{"author1"}}
+	test_feed := []*models.Post{
+		{PostID: "post"},
+		{PostID: "repost", RepostOf: "post"},
+		{PostID: "replyToFollowed", ReplyTo: "post", ReplyToUser: "author1"},
+		{PostID: "replyToViewer", ReplyTo: "post", ReplyToUser: "viewer"},
+		{PostID: "replyToStranger", ReplyTo: "post", ReplyToUser: "stranger"},
+		{PostID: "ownReply", PostedBy: "viewer", ReplyTo: "post", ReplyToUser: "stranger"},
+	}
+	ids := func(feed []*models.Post) string {
+		joined := ""
+		for _, post := range feed {
+			joined += post.PostID + " "
+		}
+		return joined
+	}
+	filtered := ids(FilterFeed(viewer, test_feed, &models.FeedOptions{IncludeReposts: true}))
+	if filtered != "post repost replyToFollowed replyToViewer ownReply " {
+		t.Errorf("Unexpected feed when only showing replies to followed users: %+v\n", filtered)
+	}
+	filtered = ids(FilterFeed(viewer, test_feed, &models.FeedOptions{Replies: models.ReplyPolicy_REPLIES_ALL}))
+	if filtered != "post replyToFollowed replyToViewer replyToStranger ownReply " {
+		t.Errorf("Unexpected feed when showing all replies without reposts: %+v\n", filtered)
+	}
+	filtered = ids(FilterFeed(viewer, test_feed, &models.FeedOptions{IncludeReposts: true, Replies: models.ReplyPolicy_REPLIES_NONE}))
+	if filtered != "post repost ownReply " {
+		t.Errorf("Unexpected feed without replies: %+v\n", filtered)
+	}
+}
+
+func TestWithoutBlocked(t *testing.T) {
+	test_feed := []*models.Post{
+		{PostID: "post", PostedBy: "author"},
+		{PostID: "blockedPost", PostedBy: "blocked"},
+		{PostID: "blockedRepost", PostedBy: "author", RepostedBy: "blocked"},
+		{PostID: "repostOfBlocked", PostedBy: "blocked", RepostedBy: "author"},
+		{PostID: "replyToBlocked", PostedBy: "author", ReplyToUser: "blocked"},
+	}
+	filtered := WithoutBlocked(test_feed, []string{"blocked"})
+	if len(filtered) != 1 || filtered[0].PostID != "post" {
+		t.Errorf("Posts of blocked user left in the feed: %+v\n", filtered)
+	}
+	if filtered := WithoutBlocked(test_feed, nil); len(filtered) != len(test_feed) {
+		t.Errorf("Posts removed without blocked users: %+v\n", filtered)
+	}
+}
+
+// unreachablePosts is a storage whose posts can't be read, like etcd timing out
+type unreachablePosts struct {
+	storage.Storage
+}
+
+func (u unreachablePosts) PostStore() storage.PostStore {
+	return failingPostStore{u.Storage.PostStore()}
+}
+
+type failingPostStore struct {
+	storage.PostStore
+}
+
+func (failingPostStore) GetPost(ctx context.Context, postId string) (*models.Post, error) {
+	return nil, errors.New("context deadline exceeded")
+}
+
+func TestPostService_ResolveReposts(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	defer db.Close()
+	test_service := New(db, time.Minute, NewDecayScorer(time.Hour))
+	now := time.Now()
+	original, _ := db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "author", Content: "original", PostedAt: timestamppb.New(now)})
+	deleted, _ := db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "author", Content: "deleted", PostedAt: timestamppb.New(now)})
+	first_repost, _ := db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "reposter1", RepostOf: original.PostID, PostedAt: timestamppb.New(now)})
+	second_repost, _ := db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "reposter2", RepostOf: original.PostID, PostedAt: timestamppb.New(now)})
+	stale_repost, _ := db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "reposter1", RepostOf: deleted.PostID, PostedAt: timestamppb.New(now)})
+	db.PostStore().DeletePost(ctx, deleted)
+
+	resolved, err := test_service.ResolveReposts(ctx, []*models.Post{first_repost, stale_repost, second_repost, original})
+	if err != nil {
+		t.Errorf("Error in resolving reposts: %+v\n", err)
+	}
+	if len(resolved) != 1 {
+		t.Fatalf("Reposts of the same post or of a deleted post not dropped: %+v\n", resolved)
+	}
+	if resolved[0].PostID != original.PostID || resolved[0].Content != "original" || resolved[0].RepostedBy != "reposter1" {
+		t.Errorf("Repost not replaced by the reposted post: %+v\n", resolved[0])
+	}
+	if original.RepostedBy != "" {
+		t.Errorf("Stored post modified while resolving: %+v\n", original)
+	}
+
+	unreachable_service := New(unreachablePosts{db}, time.Minute, NewDecayScorer(time.Hour))
+	if _, err := unreachable_service.ResolveReposts(ctx, []*models.Post{first_repost, original}); err == nil {
+		t.Error("Repost dropped when the reposted post couldn't be read")
+	}
+
+	repost, _ := test_service.GetRepost(ctx, &models.User{UserName: "reposter2"}, original.PostID)
+	if repost == nil || repost.PostID != second_repost.PostID {
+		t.Errorf("Unexpected repost of the user: %+v\n", repost)
+	}
+	repost, _ = test_service.GetRepost(ctx, &models.User{UserName: "author"}, original.PostID)
+	if repost != nil {
+		t.Errorf("Repost found for a user who didn't repost: %+v\n", repost)
+	}
+}
@@ -1,42 +1,102 @@
 package posts
 
 import (
-	"sort"
+	"context"
+	"errors"
 	"sync"
+	"time"
 
+	"github.com/twitter/hashtags"
 	"github.com/twitter/models"
 	"github.com/twitter/storage"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MaxTTL is the longest an ephemeral post can live
+const MaxTTL = 30 * 24 * time.Hour
+
+var (
+	ErrEditWindowClosed = errors.New("Post can no longer be edited")
+	ErrInvalidTTL       = errors.New("Post TTL has to be between 0 and 30 days")
 )
 
 type Service interface {
-	GetPost(string) (*models.Post, error)
-	CreatePost(*models.Post) (*models.Post, error)
-	DeletePost(*models.Post) error
-	GetAllPosts(*models.User) ([]*models.Post, error)
-	GetFeed([]*models.User) ([]*models.Post, error)
+	GetPost(ctx context.Context, postId string) (*models.Post, error)
+	CreatePost(ctx context.Context, post *models.Post) (*models.Post, error)
+	DeletePost(ctx context.Context, post *models.Post) error
+	GetAllPosts(ctx context.Context, user *models.User) ([]*models.Post, error)
+	// GetFeed returns the posts of the users, newest first
+	GetFeed(ctx context.Context, users []*models.User) ([]*models.Post, error)
+	// RankFeed orders the feed of the viewer by the score the scorer gives each post at the given time
+	RankFeed(ctx context.Context, viewer *models.User, feed []*models.Post, now time.Time) ([]*models.Post, error)
+	// AddReply counts the post towards the replies of the post it replies to, posts that aren't replies are
+	// ignored
+	AddReply(ctx context.Context, post *models.Post) error
+	RemoveReply(ctx context.Context, post *models.Post) error
+	// GetReplyCounts returns the number of replies of each of the posts, posts without replies are left out
+	GetReplyCounts(ctx context.Context, postIds []string) (map[string]int64, error)
+	// ResolveReposts replaces reposts by the posts they repost, dropping reposts of deleted posts and
+	// posts already shown earlier
+	ResolveReposts(ctx context.Context, posts []*models.Post) ([]*models.Post, error)
+	// GetRepost returns the user's repost of the post, nil if the user didn't repost it
+	GetRepost(ctx context.Context, user *models.User, postId string) (*models.Post, error)
+	// EditPost replaces the content of the post, keeping the previous content as a revision
+	EditPost(ctx context.Context, post *models.Post, content string) (*models.Post, error)
+	// GetPostHistory returns the post along with its previous revisions, oldest first
+	GetPostHistory(ctx context.Context, postId string) (*models.PostHistory, error)
+	SchedulePost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.ScheduledPost, error)
+	GetDueScheduledPosts(ctx context.Context, now time.Time) ([]*models.ScheduledPost, error)
+	// PublishScheduledPost creates the post of the scheduled post, returning nil if it was already published
+	PublishScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.Post, error)
+	PinPost(ctx context.Context, post *models.Post) error
+	// UnpinPost clears the pin of the user, or only if it is the given post when postId is not empty
+	UnpinPost(ctx context.Context, user *models.User, postId string) error
+	// GetPinnedPost returns the id of the user's pinned post, empty if nothing is pinned
+	GetPinnedPost(ctx context.Context, user *models.User) (string, error)
 }
 
 type PostService struct {
 	db storage.Storage
+	// how long after being posted a post can still be edited
+	editWindow time.Duration
+	scorer     Scorer
 }
 
-func (ps *PostService) GetPost(postId string) (*models.Post, error) {
-	return ps.db.PostStore().GetPost(postId)
+func (ps *PostService) GetPost(ctx context.Context, postId string) (*models.Post, error) {
+	return ps.db.PostStore().GetPost(ctx, postId)
 }
 
-func (ps *PostService) CreatePost(newPost *models.Post) (*models.Post, error) {
-	return ps.db.PostStore().CreatePost(newPost)
+func (ps *PostService) CreatePost(ctx context.Context, newPost *models.Post) (*models.Post, error) {
+	setExpiry(newPost)
+	return ps.db.PostStore().CreatePost(ctx, newPost)
 }
 
-func (ps *PostService) DeletePost(postToDelete *models.Post) error {
-	return ps.db.PostStore().DeletePost(postToDelete)
+// ValidateTTL checks that the TTL of an ephemeral post is within the allowed range, zero meaning no expiry
+func ValidateTTL(post *models.Post) error {
+	if post.TTLSeconds < 0 || time.Duration(post.TTLSeconds)*time.Second > MaxTTL {
+		return ErrInvalidTTL
+	}
+	return nil
 }
 
-func (ps *PostService) GetAllPosts(userData *models.User) ([]*models.Post, error) {
-	return ps.db.PostStore().GetPosts(userData)
+// setExpiry derives the expiry time of an ephemeral post from the time it was posted
+func setExpiry(post *models.Post) {
+	post.ExpiresAt = nil
+	if post.TTLSeconds > 0 {
+		post.ExpiresAt = timestamppb.New(post.PostedAt.AsTime().Add(time.Duration(post.TTLSeconds) * time.Second))
+	}
 }
 
-func (ps *PostService) GetFeed(followingList []*models.User) ([]*models.Post, error) {
+func (ps *PostService) DeletePost(ctx context.Context, postToDelete *models.Post) error {
+	return ps.db.PostStore().DeletePost(ctx, postToDelete)
+}
+
+func (ps *PostService) GetAllPosts(ctx context.Context, userData *models.User) ([]*models.Post, error) {
+	return ps.db.PostStore().GetPosts(ctx, userData)
+}
+
+func (ps *PostService) GetFeed(ctx context.Context, followingList []*models.User) ([]*models.Post, error) {
 	feed := make([]*models.Post, 0)
 	wg := sync.WaitGroup{}
 	wg.Add(len(followingList))
@@ -45,7 +105,7 @@ func (ps *PostService) GetFeed(followingList []*models.User) ([]*models.Post, er
 
 	for _, curUser := range followingList {
 		go func(cu *models.User) {
-			latestPosts, err := ps.db.PostStore().GetPosts(cu)
+			latestPosts, err := ps.db.PostStore().GetPosts(ctx, cu)
 			if err != nil {
 				wg.Done()
 				return
@@ -62,14 +122,107 @@ func (ps *PostService) GetFeed(followingList []*models.User) ([]*models.Post, er
 	}()
 
 	wg.Wait()
-	sort.Slice(feed, func(i int, j int) bool {
-		return feed[i].PostedAt.Seconds < feed[j].PostedAt.Seconds
-	})
+	SortChronologically(feed)
 	return feed, nil
 }
 
-func New(db storage.Storage) Service {
+func (ps *PostService) AddReply(ctx context.Context, post *models.Post) error {
+	if post.ReplyTo == "" {
+		return nil
+	}
+	return ps.db.PostStore().AddReply(ctx, post)
+}
+
+func (ps *PostService) GetReplyCounts(ctx context.Context, postIds []string) (map[string]int64, error) {
+	return ps.db.PostStore().GetReplyCounts(ctx, postIds)
+}
+
+func (ps *PostService) RemoveReply(ctx context.Context, post *models.Post) error {
+	if post.ReplyTo == "" {
+		return nil
+	}
+	return ps.db.PostStore().RemoveReply(ctx, post)
+}
+
+func (ps *PostService) EditPost(ctx context.Context, post *models.Post, content string) (*models.Post, error) {
+	if time.Since(post.PostedAt.AsTime()) > ps.editWindow {
+		return nil, ErrEditWindowClosed
+	}
+	previous := &models.PostRevision{
+		Content:   post.Content,
+		CreatedAt: post.PostedAt,
+	}
+	if post.EditedAt != nil {
+		previous.CreatedAt = post.EditedAt
+	}
+	editedPost := proto.Clone(post).(*models.Post)
+	editedPost.Content = content
+	editedPost.Hashtags = hashtags.Extract(content)
+	editedPost.EditedAt = timestamppb.Now()
+	return ps.db.PostStore().EditPost(ctx, editedPost, previous)
+}
+
+func (ps *PostService) GetPostHistory(ctx context.Context, postId string) (*models.PostHistory, error) {
+	post, err := ps.db.PostStore().GetPost(ctx, postId)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := ps.db.PostStore().GetPostRevisions(ctx, postId)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PostHistory{
+		Post:      post,
+		Revisions: revisions,
+	}, nil
+}
+
+func (ps *PostService) SchedulePost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.ScheduledPost, error) {
+	return ps.db.ScheduleStore().AddScheduledPost(ctx, scheduledPost)
+}
+
+func (ps *PostService) GetDueScheduledPosts(ctx context.Context, now time.Time) ([]*models.ScheduledPost, error) {
+	return ps.db.ScheduleStore().GetDueScheduledPosts(ctx, now)
+}
+
+func (ps *PostService) PublishScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.Post, error) {
+	scheduledPost.Post.PostedAt = timestamppb.Now()
+	setExpiry(scheduledPost.Post)
+	return ps.db.ScheduleStore().PublishScheduledPost(ctx, scheduledPost)
+}
+
+func (ps *PostService) PinPost(ctx context.Context, post *models.Post) error {
+	return ps.db.UserStore().PinPost(ctx, post.PostedBy, post.PostID)
+}
+
+func (ps *PostService) UnpinPost(ctx context.Context, user *models.User, postId string) error {
+	return ps.db.UserStore().UnpinPost(ctx, user.UserName, postId)
+}
+
+func (ps *PostService) GetPinnedPost(ctx context.Context, user *models.User) (string, error) {
+	return ps.db.UserStore().GetPinnedPost(ctx, user.UserName)
+}
+
+// PinFirst moves the pinned post to the front, leaving the order of the other posts untouched
+func PinFirst(posts []*models.Post, pinnedPostId string) []*models.Post {
+	if pinnedPostId == "" {
+		return posts
+	}
+	ordered := make([]*models.Post, 0, len(posts))
+	for _, post := range posts {
+		if post.PostID == pinnedPostId {
+			ordered = append([]*models.Post{post}, ordered...)
+		} else {
+			ordered = append(ordered, post)
+		}
+	}
+	return ordered
+}
+
+func New(db storage.Storage, editWindow time.Duration, scorer Scorer) Service {
 	return &PostService{
-		db: db,
+		db:         db,
+		editWindow: editWindow,
+		scorer:     scorer,
 	}
 }
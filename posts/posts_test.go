@@ -0,0 +1,23 @@
+package posts
+
+import (
+	"testing"
+
+	"github.com/twitter/models"
+)
+
+func TestPinFirst(t *testing.T) {
+	test_posts := []*models.Post{{PostID: "1"}, {PostID: "2"}, {PostID: "3"}}
+	ordered := PinFirst(test_posts, "2")
+	if len(ordered) != 3 || ordered[0].PostID != "2" || ordered[1].PostID != "1" || ordered[2].PostID != "3" {
+		t.Errorf("Pinned post not moved to the front: %+v\n", ordered)
+	}
+	ordered = PinFirst(test_posts, "missing")
+	if len(ordered) != 3 || ordered[0].PostID != "1" {
+		t.Errorf("Order changed for a missing pinned post: %+v\n", ordered)
+	}
+	ordered = PinFirst(test_posts, "")
+	if len(ordered) != 3 || ordered[0].PostID != "1" {
+		t.Errorf("Order changed without a pinned post: %+v\n", ordered)
+	}
+}
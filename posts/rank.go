@@ -0,0 +1,119 @@
+package posts
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/twitter/models"
+)
+
+// Signals are what a post is ranked on in the viewer's feed
+type Signals struct {
+	// how long ago the post was posted
+	Age     time.Duration
+	Likes   int
+	Replies int64
+	// number of times the viewer replied to or liked posts of the author
+	Interactions int
+}
+
+// Scorer ranks posts of the feed, posts with a higher score come first
+type Scorer interface {
+	Score(post *models.Post, signals Signals) float64
+}
+
+// DecayScorer weighs the engagement of a post and halves its score every HalfLife
+type DecayScorer struct {
+	HalfLife          time.Duration
+	LikeWeight        float64
+	ReplyWeight       float64
+	InteractionWeight float64
+}
+
+func (d *DecayScorer) Score(_ *models.Post, signals Signals) float64 {
+	engagement := 1 +
+		d.LikeWeight*float64(signals.Likes) +
+		d.ReplyWeight*float64(signals.Replies) +
+		d.InteractionWeight*float64(signals.Interactions)
+	age := signals.Age
+	if age < 0 {
+		age = 0
+	}
+	return engagement * math.Exp2(-age.Hours()/d.HalfLife.Hours())
+}
+
+// NewDecayScorer returns a DecayScorer counting a reply twice as much as a like
+func NewDecayScorer(halfLife time.Duration) Scorer {
+	return &DecayScorer{
+		HalfLife:          halfLife,
+		LikeWeight:        1,
+		ReplyWeight:       2,
+		InteractionWeight: 0.5,
+	}
+}
+
+// SortChronologically orders the posts newest first, posts of the same instant are ordered by id so the
+// order is stable between requests
+func SortChronologically(posts []*models.Post) {
+	sort.Slice(posts, func(i int, j int) bool {
+		iTime, jTime := posts[i].PostedAt.AsTime(), posts[j].PostedAt.AsTime()
+		if !iTime.Equal(jTime) {
+			return iTime.After(jTime)
+		}
+		return posts[i].PostID > posts[j].PostID
+	})
+}
+
+// interactionCounts counts per author how often the viewer replied to them, going by the viewer's own posts,
+// and how many of their posts in the feed the viewer liked
+func interactionCounts(viewer string, viewerPosts []*models.Post, feed []*models.Post) map[string]int {
+	interactions := make(map[string]int)
+	for _, post := range viewerPosts {
+		if post.ReplyToUser != "" && post.ReplyToUser != viewer {
+			interactions[post.ReplyToUser] += 1
+		}
+	}
+	for _, post := range feed {
+		for _, likedBy := range post.LikedBy {
+			if likedBy == viewer {
+				interactions[post.PostedBy] += 1
+				break
+			}
+		}
+	}
+	return interactions
+}
+
+func (ps *PostService) RankFeed(ctx context.Context, viewer *models.User, feed []*models.Post, now time.Time) ([]*models.Post, error) {
+	viewerPosts, err := ps.db.PostStore().GetPosts(ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+	postIds := make([]string, 0, len(feed))
+	for _, post := range feed {
+		postIds = append(postIds, post.PostID)
+	}
+	replyCounts, err := ps.db.PostStore().GetReplyCounts(ctx, postIds)
+	if err != nil {
+		return nil, err
+	}
+	interactions := interactionCounts(viewer.UserName, viewerPosts, feed)
+
+	scores := make(map[string]float64, len(feed))
+	for _, post := range feed {
+		scores[post.PostID] = ps.scorer.Score(post, Signals{
+			Age:          now.Sub(post.PostedAt.AsTime()),
+			Likes:        len(post.LikedBy),
+			Replies:      replyCounts[post.PostID],
+			Interactions: interactions[post.PostedBy],
+		})
+	}
+	ranked := append([]*models.Post{}, feed...)
+	SortChronologically(ranked)
+	sort.SliceStable(ranked, func(i int, j int) bool {
+		return scores[ranked[i].PostID] > scores[ranked[j].PostID]
+	})
+	return ranked, nil
+}
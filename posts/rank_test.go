@@ -0,0 +1,111 @@
+package posts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage/memory"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDecayScorer_Score(t *testing.T) {
+	test_scorer := NewDecayScorer(time.Hour)
+	fresh := test_scorer.Score(nil, Signals{})
+	if fresh != 1 {
+		t.Errorf("Unexpected score of a fresh post without engagement: %+v\n", fresh)
+	}
+	halved := test_scorer.Score(nil, Signals{Age: time.Hour})
+	if halved != 0.5 {
+		t.Errorf("Score not halved after one half life: %+v\n", halved)
+	}
+	liked := test_scorer.Score(nil, Signals{Likes: 1})
+	replied := test_scorer.Score(nil, Signals{Replies: 1})
+	interacted := test_scorer.Score(nil, Signals{Interactions: 1})
+	if !(replied > liked && liked > interacted && interacted > fresh) {
+		t.Errorf("Unexpected weights, like: %+v reply: %+v interaction: %+v\n", liked, replied, interacted)
+	}
+	future := test_scorer.Score(nil, Signals{Age: -time.Hour})
+	if future != fresh {
+		t.Errorf("Post from the future scored above a fresh one: %+v\n", future)
+	}
+}
+
+func TestSortChronologically(t *testing.T) {
+	now := time.Now()
+	test_posts := []*models.Post{
+		{PostID: "a", PostedAt: timestamppb.New(now)},
+		{PostID: "b", PostedAt: timestamppb.New(now.Add(time.Millisecond))},
+		{PostID: "c", PostedAt: timestamppb.New(now)},
+		{PostID: "d", PostedAt: timestamppb.New(now.Add(-time.Hour))},
+	}
+	SortChronologically(test_posts)
+	order := ""
+	for _, post := range test_posts {
+		order += post.PostID
+	}
+	if order != "bcad" {
+		t.Errorf("Posts not ordered newest first: %+v\n", order)
+	}
+}
+
+// fixedScorer scores posts by a fixed score per id
+type fixedScorer map[string]float64
+
+func (f fixedScorer) Score(post *models.Post, _ Signals) float64 {
+	return f[post.PostID]
+}
+
+// signalsScorer records the signals it was given
+type signalsScorer map[string]Signals
+
+func (s signalsScorer) Score(post *models.Post, signals Signals) float64 {
+	s[post.PostID] = signals
+	return 0
+}
+
+func TestPostService_RankFeed(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	defer db.Close()
+	now := time.Now()
+	viewer := &models.User{UserName: "viewer"}
+	first_post, _ := db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "author1", PostedAt: timestamppb.New(now.Add(-time.Hour))})
+	second_post, _ := db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "author2", PostedAt: timestamppb.New(now), LikedBy: []string{"viewer", "other"}})
+	for _, replyTo := range []*models.Post{first_post, first_post, second_post} {
+		reply, _ := db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "viewer", PostedAt: timestamppb.New(now), ReplyTo: replyTo.PostID, ReplyToUser: replyTo.PostedBy})
+		db.PostStore().AddReply(ctx, reply)
+	}
+	feed := []*models.Post{first_post, second_post}
+
+	recorded_signals := signalsScorer{}
+	test_service := New(db, time.Minute, recorded_signals)
+	test_service.RankFeed(ctx, viewer, feed, now)
+	first_signals := recorded_signals[first_post.PostID]
+	if first_signals.Age != time.Hour || first_signals.Replies != 2 || first_signals.Likes != 0 || first_signals.Interactions != 2 {
+		t.Errorf("Unexpected signals of the first post: %+v\n", first_signals)
+	}
+	second_signals := recorded_signals[second_post.PostID]
+	if second_signals.Age != 0 || second_signals.Replies != 1 || second_signals.Likes != 2 || second_signals.Interactions != 2 {
+		t.Errorf("Unexpected signals of the second post: %+v\n", second_signals)
+	}
+
+	test_service = New(db, time.Minute, fixedScorer{first_post.PostID: 2, second_post.PostID: 1})
+	ranked, err := test_service.RankFeed(ctx, viewer, feed, now)
+	if err != nil {
+		t.Errorf("Error in ranking feed: %+v\n", err)
+	}
+	if len(ranked) != 2 || ranked[0] != first_post || ranked[1] != second_post {
+		t.Errorf("Feed not ordered by score: %+v\n", ranked)
+	}
+	if feed[0] != first_post || feed[1] != second_post {
+		t.Errorf("Ranking modified the given feed: %+v\n", feed)
+	}
+
+	test_service = New(db, time.Minute, fixedScorer{})
+	ranked, _ = test_service.RankFeed(ctx, viewer, feed, now)
+	if ranked[0] != second_post {
+		t.Errorf("Posts of the same score not ordered newest first: %+v\n", ranked)
+	}
+}
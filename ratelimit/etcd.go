@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	etcdPrefix = "twitter-key-ratelimits"
+	// the buckets are put under a lease replaced once half of it passed, the buckets that weren't taken from for
+	// longer than that expire with it and are full again, long after they refilled
+	leaseTTL = 10 * time.Minute
+	// a token is only refused once the bucket kept changing under this many attempts to take it
+	maxTxnAttempts = 5
+)
+
+// bucketState is the bucket as stored in etcd
+type bucketState struct {
+	Tokens float64 `json:"tokens"`
+	// unix time in nanoseconds the tokens were counted at
+	Last int64 `json:"last"`
+}
+
+// etcdStore keeps the buckets in etcd, so that the limits hold across all the servers
+type etcdStore struct {
+	client  *clientv3.Client
+	mtx     sync.Mutex
+	lease   clientv3.LeaseID
+	renewAt time.Time
+}
+
+// NewEtcdStore creates the store of buckets shared by the servers using the etcd cluster
+func NewEtcdStore(client *clientv3.Client) Store {
+	return &etcdStore{client: client}
+}
+
+func (e *etcdStore) currentLease(ctx context.Context, now time.Time) (clientv3.LeaseID, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.lease != clientv3.NoLease && now.Before(e.renewAt) {
+		return e.lease, nil
+	}
+	resp, err := e.client.Grant(ctx, int64(leaseTTL.Seconds()))
+	if err != nil {
+		return clientv3.NoLease, err
+	}
+	e.lease, e.renewAt = resp.ID, now.Add(leaseTTL/2)
+	return e.lease, nil
+}
+
+// Take takes the token in a transaction that only succeeds if no other server changed the bucket in the meantime
+func (e *etcdStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (bool, error) {
+	etcdKey := etcdPrefix + "/" + key
+	for attempt := 0; attempt < maxTxnAttempts; attempt++ {
+		resp, err := e.client.Get(ctx, etcdKey)
+		if err != nil {
+			return false, err
+		}
+		state := bucketState{Tokens: float64(limit.Burst), Last: now.UnixNano()}
+		unchanged := clientv3.Compare(clientv3.CreateRevision(etcdKey), "=", 0)
+		if len(resp.Kvs) > 0 {
+			if err := json.Unmarshal(resp.Kvs[0].Value, &state); err != nil {
+				return false, err
+			}
+			unchanged = clientv3.Compare(clientv3.ModRevision(etcdKey), "=", resp.Kvs[0].ModRevision)
+		}
+		last := time.Unix(0, state.Last)
+		tokens := limit.refill(state.Tokens, last, now)
+		if tokens < 1 {
+			return false, nil
+		}
+		// the clock of the server that last took a token may be ahead of this one
+		if now.After(last) {
+			last = now
+		}
+		lease, err := e.currentLease(ctx, now)
+		if err != nil {
+			return false, err
+		}
+		value, err := json.Marshal(bucketState{Tokens: tokens - 1, Last: last.UnixNano()})
+		if err != nil {
+			return false, err
+		}
+		txn, err := e.client.Txn(ctx).
+			If(unchanged).
+			Then(clientv3.OpPut(etcdKey, string(value), clientv3.WithLease(lease))).
+			Commit()
+		if err != nil {
+			return false, err
+		}
+		if txn.Succeeded {
+			return true, nil
+		}
+	}
+	return false, nil
+}
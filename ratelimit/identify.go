@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientIPKey is the metadata the web tier passes the IP address of its clients on in
+const ClientIPKey = "x-forwarded-for"
+
+// UserOrIP identifies the requests by the user of their token, as verified by verify, or by the IP address they came
+// from. The address the proxies pass on is used for the requests of the trusted proxies, such as the web tier.
+func UserOrIP(verify func(token string) (string, error), trustedProxies []string) Identify {
+	trusted := map[string]bool{}
+	for _, proxy := range trustedProxies {
+		trusted[proxy] = true
+	}
+	return func(ctx context.Context) string {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if tokens := md.Get("token"); len(tokens) > 0 && tokens[0] != "" {
+			// a token that doesn't verify is refused by the service, it's limited by address until then
+			if userName, err := verify(tokens[0]); err == nil {
+				return "user/" + userName
+			}
+		}
+		ip := peerIP(ctx)
+		if forwarded := md.Get(ClientIPKey); trusted[ip] && len(forwarded) > 0 && forwarded[0] != "" {
+			ip = forwarded[0]
+		}
+		return "ip/" + ip
+	}
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// ForwardClientIP passes the IP address of the client on to the service, so that the requests of the clients who
+// haven't logged in aren't all limited as the requests of the web tier
+func ForwardClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		ctx := metadata.AppendToOutgoingContext(r.Context(), ClientIPKey, host)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
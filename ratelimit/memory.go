@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the buckets that refilled completely are dropped, a missing bucket is a full one
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  Limit
+}
+
+// memoryStore keeps the buckets of a single server
+type memoryStore struct {
+	mtx       sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates the store of buckets that only hold within the server
+func NewMemoryStore() Store {
+	return &memoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (m *memoryStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if now.Sub(m.lastSweep) > sweepInterval {
+		m.sweep(now)
+	}
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now, limit: limit}
+		m.buckets[key] = b
+	}
+	b.tokens = limit.refill(b.tokens, b.last, now)
+	b.last = now
+	if b.tokens < 1 {
+		return false, nil
+	}
+	b.tokens--
+	return true, nil
+}
+
+func (m *memoryStore) sweep(now time.Time) {
+	for key, b := range m.buckets {
+		if b.limit.refill(b.tokens, b.last, now) >= float64(b.limit.Burst) {
+			delete(m.buckets, key)
+		}
+	}
+	m.lastSweep = now
+}
@@ -0,0 +1,151 @@
+// Package ratelimit limits the rate of the RPCs of every user, or of every IP address for the requests made without
+// logging in, with token buckets per class of RPCs
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/twitter/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// the classes of RPCs, each has its own limit
+const (
+	Reads  = "reads"
+	Writes = "writes"
+	Logins = "logins"
+)
+
+// classes maps the RPCs to their class, the RPCs without one, such as the health checks, aren't limited
+var classes = map[string]string{
+	"/twitter.Twitter/LoginUser":          Logins,
+	"/twitter.Twitter/RegisterUser":       Logins,
+	"/twitter.Twitter/GetFeed":            Reads,
+	"/twitter.Twitter/GetUser":            Reads,
+	"/twitter.Twitter/GetUserProfile":     Reads,
+	"/twitter.Twitter/GetSelf":            Reads,
+	"/twitter.Twitter/GetMyPosts":         Reads,
+	"/twitter.Twitter/GetPost":            Reads,
+	"/twitter.Twitter/GetHashtagTimeline": Reads,
+	"/twitter.Twitter/GetTrending":        Reads,
+	"/twitter.Twitter/Search":             Reads,
+	"/twitter.Twitter/GetMedia":           Reads,
+	"/twitter.Twitter/GetPostHistory":     Reads,
+	"/twitter.Twitter/ListBookmarks":      Reads,
+	"/twitter.Twitter/GetList":            Reads,
+	"/twitter.Twitter/GetUserLists":       Reads,
+	"/twitter.Twitter/GetListTimeline":    Reads,
+	"/twitter.Twitter/SuggestUsers":       Reads,
+	"/twitter.Twitter/GetPostStats":       Reads,
+	"/twitter.Twitter/FollowUser":         Writes,
+	"/twitter.Twitter/UnFollowUser":       Writes,
+	"/twitter.Twitter/CreatePost":         Writes,
+	"/twitter.Twitter/DeletePost":         Writes,
+	"/twitter.Twitter/UploadMedia":        Writes,
+	"/twitter.Twitter/EditPost":           Writes,
+	"/twitter.Twitter/SchedulePost":       Writes,
+	"/twitter.Twitter/BookmarkPost":       Writes,
+	"/twitter.Twitter/RemoveBookmark":     Writes,
+	"/twitter.Twitter/PinPost":            Writes,
+	"/twitter.Twitter/UnpinPost":          Writes,
+	"/twitter.Twitter/VotePoll":           Writes,
+	"/twitter.Twitter/CreateList":         Writes,
+	"/twitter.Twitter/UpdateList":         Writes,
+	"/twitter.Twitter/DeleteList":         Writes,
+	"/twitter.Twitter/AddListMember":      Writes,
+	"/twitter.Twitter/RemoveListMember":   Writes,
+	"/twitter.Twitter/BlockUser":          Writes,
+	"/twitter.Twitter/UnBlockUser":        Writes,
+	"/twitter.Twitter/Repost":             Writes,
+	"/twitter.Twitter/UnRepost":           Writes,
+}
+
+// Limit is a token bucket refilled at PerSecond tokens a second up to Burst tokens, every RPC takes a token
+type Limit struct {
+	PerSecond float64
+	Burst     int
+}
+
+// refill returns the tokens in the bucket, holding tokens at last, once it was refilled until now
+func (l Limit) refill(tokens float64, last time.Time, now time.Time) float64 {
+	if elapsed := now.Sub(last).Seconds(); elapsed > 0 {
+		tokens += elapsed * l.PerSecond
+	}
+	if tokens > float64(l.Burst) {
+		return float64(l.Burst)
+	}
+	return tokens
+}
+
+// Store keeps the buckets
+type Store interface {
+	// Take takes a token from the bucket of the key, reporting false when the bucket is empty
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (bool, error)
+}
+
+// Identify returns who made the request, the requests of the same identity share their buckets
+type Identify func(ctx context.Context) string
+
+type Limiter struct {
+	store    Store
+	limits   map[string]Limit
+	identify Identify
+	now      func() time.Time
+}
+
+// New creates the limiter of the classes of RPCs with a limit, keeping the buckets in the store
+func New(store Store, limits map[string]Limit, identify Identify) *Limiter {
+	return &Limiter{
+		store:    store,
+		limits:   limits,
+		identify: identify,
+		now:      time.Now,
+	}
+}
+
+// allow takes a token from the bucket of the identity and class of the RPC, the RPC is let through when the store
+// fails so that the service doesn't go down with it
+func (l *Limiter) allow(ctx context.Context, fullMethod string) error {
+	class, ok := classes[fullMethod]
+	if !ok {
+		return nil
+	}
+	limit, ok := l.limits[class]
+	if !ok {
+		return nil
+	}
+	identity := l.identify(ctx)
+	allowed, err := l.store.Take(ctx, class+"/"+identity, limit, l.now())
+	if err != nil {
+		logging.FromContext(ctx).Warn("Unable to apply the rate limit, allowing the request", "err", err)
+		return nil
+	}
+	if !allowed {
+		return status.Errorf(codes.ResourceExhausted, "Too many %s, try again later", class)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor refuses the RPCs over the limit of their class with ResourceExhausted
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor refuses the streams over the limit of their class with ResourceExhausted, a stream takes
+// a single token however many messages it carries
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
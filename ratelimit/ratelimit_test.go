@@ -0,0 +1,153 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/twitter/twitter"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// failingStore is a store that can't be reached
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func TestClasses(t *testing.T) {
+	for _, method := range twitter.Twitter_ServiceDesc.Methods {
+		fullMethod := "/" + twitter.Twitter_ServiceDesc.ServiceName + "/" + method.MethodName
+		if _, ok := classes[fullMethod]; !ok && method.MethodName != "HealthCheck" {
+			t.Errorf("No class of %+v\n", fullMethod)
+		}
+	}
+	for _, stream := range twitter.Twitter_ServiceDesc.Streams {
+		fullMethod := "/" + twitter.Twitter_ServiceDesc.ServiceName + "/" + stream.StreamName
+		if _, ok := classes[fullMethod]; !ok {
+			t.Errorf("No class of %+v\n", fullMethod)
+		}
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore().(*memoryStore)
+	limit := Limit{PerSecond: 2, Burst: 3}
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if allowed, _ := store.Take(ctx, "writes/user/alice", limit, now); !allowed {
+			t.Errorf("Token %+v of the burst refused\n", i)
+		}
+	}
+	if allowed, _ := store.Take(ctx, "writes/user/alice", limit, now); allowed {
+		t.Error("Token taken from an empty bucket")
+	}
+	if allowed, _ := store.Take(ctx, "writes/user/bob", limit, now); !allowed {
+		t.Error("Buckets of different keys shared")
+	}
+	if allowed, _ := store.Take(ctx, "writes/user/alice", limit, now.Add(500*time.Millisecond)); !allowed {
+		t.Error("Bucket not refilled")
+	}
+	if allowed, _ := store.Take(ctx, "writes/user/alice", limit, now.Add(500*time.Millisecond)); allowed {
+		t.Error("Bucket refilled by more than the rate")
+	}
+
+	store.Take(ctx, "writes/user/carol", limit, now.Add(sweepInterval+time.Second))
+	if _, ok := store.buckets["writes/user/alice"]; ok {
+		t.Error("Full bucket not swept")
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	identify := func(ctx context.Context) string { return "user/alice" }
+	limiter := New(NewMemoryStore(), map[string]Limit{Writes: {PerSecond: 1, Burst: 2}}, identify)
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	interceptor := limiter.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "done", nil }
+	call := func(method string) error {
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := call("/twitter.Twitter/CreatePost"); err != nil {
+			t.Errorf("RPC within the limit refused: %+v\n", err)
+		}
+	}
+	if err := call("/twitter.Twitter/FollowUser"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("RPC over the limit of its class allowed: %+v\n", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := call("/twitter.Twitter/GetFeed"); err != nil {
+			t.Errorf("RPC of a class without a limit refused: %+v\n", err)
+		}
+		if err := call("/grpc.health.v1.Health/Check"); err != nil {
+			t.Errorf("RPC without a class refused: %+v\n", err)
+		}
+	}
+	now = now.Add(time.Second)
+	if err := call("/twitter.Twitter/CreatePost"); err != nil {
+		t.Errorf("RPC refused once the bucket refilled: %+v\n", err)
+	}
+
+	failing := New(failingStore{}, map[string]Limit{Writes: {PerSecond: 1, Burst: 1}}, identify)
+	if _, err := failing.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/twitter.Twitter/CreatePost"}, handler); err != nil {
+		t.Errorf("RPC refused when the store failed: %+v\n", err)
+	}
+}
+
+func TestUserOrIP(t *testing.T) {
+	verify := func(token string) (string, error) {
+		if token != "valid" {
+			return "", errors.New("Invalid Token")
+		}
+		return "alice", nil
+	}
+	identify := UserOrIP(verify, []string{"10.0.0.1"})
+	contextFrom := func(peerIP string, pairs ...string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(peerIP), Port: 4242}})
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(pairs...))
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"user", contextFrom("10.0.0.2", "token", "valid"), "user/alice"},
+		{"invalid token", contextFrom("10.0.0.2", "token", "forged"), "ip/10.0.0.2"},
+		{"trusted proxy", contextFrom("10.0.0.1", ClientIPKey, "192.0.2.7"), "ip/192.0.2.7"},
+		{"untrusted proxy", contextFrom("10.0.0.2", ClientIPKey, "192.0.2.7"), "ip/10.0.0.2"},
+	}
+	for _, tt := range tests {
+		if got := identify(tt.ctx); got != tt.want {
+			t.Errorf("%+v identified as %+v, want %+v\n", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestForwardClientIP(t *testing.T) {
+	var forwarded []string
+	handler := ForwardClientIP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		md, _ := metadata.FromOutgoingContext(r.Context())
+		forwarded = md.Get(ClientIPKey)
+	}))
+	request := httptest.NewRequest("GET", "/home", nil)
+	request.RemoteAddr = "192.0.2.7:5000"
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	if len(forwarded) != 1 || forwarded[0] != "192.0.2.7" {
+		t.Errorf("Client IP not passed on: %+v\n", forwarded)
+	}
+}
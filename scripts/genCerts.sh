@@ -0,0 +1,24 @@
+#!/bin/bash
+
+# Generates a CA, with server and client certificates signed by it, for trying out TLS locally.
+# Usage: ./scripts/genCerts.sh [directory], the certificates are written to certs/ by default
+set -e
+DIR=${1:-certs}
+mkdir -p ${DIR}
+
+openssl req -x509 -newkey rsa:2048 -nodes -days 365 -subj "/CN=twitter-ca" \
+	-keyout ${DIR}/ca-key.pem -out ${DIR}/ca.pem
+
+# server certificate, used by cmd/server and etcd, which also presents it as a client to the other members
+openssl req -newkey rsa:2048 -nodes -subj "/CN=localhost" -keyout ${DIR}/server-key.pem -out ${DIR}/server.csr
+openssl x509 -req -in ${DIR}/server.csr -CA ${DIR}/ca.pem -CAkey ${DIR}/ca-key.pem -CAcreateserial -days 365 \
+	-extfile <(printf "subjectAltName=DNS:localhost,IP:127.0.0.1\nextendedKeyUsage=serverAuth,clientAuth") \
+	-out ${DIR}/server.pem
+
+# client certificate, used by cmd/web and by cmd/server towards etcd
+openssl req -newkey rsa:2048 -nodes -subj "/CN=twitter-client" -keyout ${DIR}/client-key.pem -out ${DIR}/client.csr
+openssl x509 -req -in ${DIR}/client.csr -CA ${DIR}/ca.pem -CAkey ${DIR}/ca-key.pem -CAcreateserial -days 365 \
+	-extfile <(printf "extendedKeyUsage=clientAuth") \
+	-out ${DIR}/client.pem
+
+rm ${DIR}/*.csr
@@ -0,0 +1,307 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+)
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 50
+
+	// BM25 parameters used to rank posts
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Service keeps an in-memory inverted index of posts and users. The index is
+// local to the process, every server replica seeds its own copy and keeps it
+// current with the changes made through all of them, see Sync.
+type Service interface {
+	IndexPost(*models.Post)
+	RemovePost(*models.Post)
+	IndexUser(*models.User)
+	// SearchPosts returns a page of matching post ids, best match first, and the total number of matches. The posts
+	// written by or replying to the excluded users aren't matched.
+	SearchPosts(query string, excluded []string, offset int, limit int) ([]string, int)
+	// SearchUsers returns a page of matching usernames, best match first, and the total number of matches. The
+	// excluded users aren't matched.
+	SearchUsers(query string, excluded []string, offset int, limit int) ([]string, int)
+}
+
+type postDocument struct {
+	terms       map[string]int
+	length      int
+	postedAt    time.Time
+	postedBy    string
+	replyToUser string
+}
+
+type SearchService struct {
+	mtx            sync.RWMutex
+	postDocs       map[string]*postDocument
+	postings       map[string]map[string]int
+	totalPostTerms int
+	userNames      map[string]string
+}
+
+type scoredResult struct {
+	id    string
+	score float64
+	// used to break ties between equally scored results, newer first
+	postedAt time.Time
+}
+
+// Tokenize splits the text into lowercase words, dropping punctuation and the '#' and '@' markers
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func (ss *SearchService) IndexPost(post *models.Post) {
+	terms := make(map[string]int)
+	tokens := Tokenize(post.Content)
+	for _, token := range tokens {
+		terms[token] += 1
+	}
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+	ss.removePost(post.PostID)
+	ss.postDocs[post.PostID] = &postDocument{
+		terms:       terms,
+		length:      len(tokens),
+		postedAt:    post.PostedAt.AsTime(),
+		postedBy:    post.PostedBy,
+		replyToUser: post.ReplyToUser,
+	}
+	ss.totalPostTerms += len(tokens)
+	for term, frequency := range terms {
+		if ss.postings[term] == nil {
+			ss.postings[term] = make(map[string]int)
+		}
+		ss.postings[term][post.PostID] = frequency
+	}
+}
+
+func (ss *SearchService) RemovePost(post *models.Post) {
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+	ss.removePost(post.PostID)
+}
+
+// removePost expects the caller to hold the write lock
+func (ss *SearchService) removePost(postId string) {
+	doc, exists := ss.postDocs[postId]
+	if !exists {
+		return
+	}
+	for term := range doc.terms {
+		delete(ss.postings[term], postId)
+		if len(ss.postings[term]) == 0 {
+			delete(ss.postings, term)
+		}
+	}
+	ss.totalPostTerms -= doc.length
+	delete(ss.postDocs, postId)
+}
+
+func (ss *SearchService) IndexUser(user *models.User) {
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+	ss.userNames[user.UserName] = strings.ToLower(user.UserName)
+}
+
+func (ss *SearchService) SearchPosts(query string, excluded []string, offset int, limit int) ([]string, int) {
+	queryTerms := uniqueTerms(Tokenize(query))
+	ss.mtx.RLock()
+	numDocs := len(ss.postDocs)
+	if numDocs == 0 || len(queryTerms) == 0 {
+		ss.mtx.RUnlock()
+		return []string{}, 0
+	}
+	avgLength := float64(ss.totalPostTerms) / float64(numDocs)
+	scores := make(map[string]float64)
+	for _, term := range queryTerms {
+		matchingDocs := ss.postings[term]
+		if len(matchingDocs) == 0 {
+			continue
+		}
+		idf := math.Log(1 + (float64(numDocs)-float64(len(matchingDocs))+0.5)/(float64(len(matchingDocs))+0.5))
+		for postId, frequency := range matchingDocs {
+			docLength := float64(ss.postDocs[postId].length)
+			tf := float64(frequency)
+			scores[postId] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*docLength/avgLength))
+		}
+	}
+	excludedUsers := toSet(excluded)
+	results := make([]scoredResult, 0, len(scores))
+	for postId, score := range scores {
+		doc := ss.postDocs[postId]
+		if excludedUsers[doc.postedBy] || excludedUsers[doc.replyToUser] {
+			continue
+		}
+		results = append(results, scoredResult{id: postId, score: score, postedAt: doc.postedAt})
+	}
+	ss.mtx.RUnlock()
+
+	sort.Slice(results, func(i int, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		if !results[i].postedAt.Equal(results[j].postedAt) {
+			return results[i].postedAt.After(results[j].postedAt)
+		}
+		return results[i].id < results[j].id
+	})
+	return paginate(results, offset, limit), len(results)
+}
+
+func (ss *SearchService) SearchUsers(query string, excluded []string, offset int, limit int) ([]string, int) {
+	queryTerms := uniqueTerms(Tokenize(query))
+	if len(queryTerms) == 0 {
+		return []string{}, 0
+	}
+	excludedUsers := toSet(excluded)
+	ss.mtx.RLock()
+	results := make([]scoredResult, 0)
+	for userName, lowerUserName := range ss.userNames {
+		if excludedUsers[userName] {
+			continue
+		}
+		score := 0.0
+		for _, term := range queryTerms {
+			switch {
+			case lowerUserName == term:
+				score += 3
+			case strings.HasPrefix(lowerUserName, term):
+				score += 2
+			case strings.Contains(lowerUserName, term):
+				score += 1
+			}
+		}
+		if score > 0 {
+			results = append(results, scoredResult{id: userName, score: score})
+		}
+	}
+	ss.mtx.RUnlock()
+
+	sort.Slice(results, func(i int, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return results[i].id < results[j].id
+	})
+	return paginate(results, offset, limit), len(results)
+}
+
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool)
+	unique := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			unique = append(unique, term)
+		}
+	}
+	return unique
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+func paginate(results []scoredResult, offset int, limit int) []string {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	ids := make([]string, 0, limit)
+	for idx := offset; idx < len(results) && idx < offset+limit; idx++ {
+		ids = append(ids, results[idx].id)
+	}
+	return ids
+}
+
+// Seed indexes every user and post already present in the storage
+func Seed(ctx context.Context, searchService Service, db storage.Storage) error {
+	allUsers, err := db.UserStore().GetAllUsers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, curUser := range allUsers {
+		searchService.IndexUser(curUser)
+	}
+	allPosts, err := db.PostStore().GetAllPosts(ctx)
+	if err != nil {
+		return err
+	}
+	for _, curPost := range allPosts {
+		indexPostEvent(searchService, storage.PostEvent{Post: curPost})
+	}
+	return nil
+}
+
+// Sync seeds the index, then returns the function applying the changes of the posts and users made through any
+// server after the seeding to the index, which runs until ctx is done. No change is missed between the two since the
+// changes are watched before seeding.
+func Sync(ctx context.Context, searchService Service, db storage.Storage) (func(), error) {
+	postEvents := db.PostStore().WatchPosts(ctx)
+	changedUsers := db.UserStore().WatchUsers(ctx)
+	if err := Seed(ctx, searchService, db); err != nil {
+		return nil, err
+	}
+	return func() {
+		for postEvents != nil || changedUsers != nil {
+			select {
+			case postEvent, ok := <-postEvents:
+				if !ok {
+					postEvents = nil
+					continue
+				}
+				indexPostEvent(searchService, postEvent)
+			case changedUser, ok := <-changedUsers:
+				if !ok {
+					changedUsers = nil
+					continue
+				}
+				searchService.IndexUser(changedUser)
+			}
+		}
+	}, nil
+}
+
+// indexPostEvent leaves the reposts out of the index as they have no content
+func indexPostEvent(searchService Service, postEvent storage.PostEvent) {
+	switch {
+	case postEvent.Deleted:
+		searchService.RemovePost(postEvent.Post)
+	case postEvent.Post.RepostOf == "":
+		searchService.IndexPost(postEvent.Post)
+	}
+}
+
+func New() Service {
+	return &SearchService{
+		postDocs:  make(map[string]*postDocument),
+		postings:  make(map[string]map[string]int),
+		userNames: make(map[string]string),
+	}
+}
@@ -0,0 +1,124 @@
+package search
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage/memory"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestPost(postId string, content string, postedAt time.Time) *models.Post {
+	return &models.Post{
+		PostID:   postId,
+		PostedBy: "test1",
+		Content:  content,
+		PostedAt: timestamppb.New(postedAt),
+	}
+}
+
+func TestSearchService_SearchPosts(t *testing.T) {
+	test_search_service := New()
+	now := time.Now()
+	test_search_service.IndexPost(newTestPost("1", "etcd uses raft for consensus", now.Add(-3*time.Minute)))
+	test_search_service.IndexPost(newTestPost("2", "Raft, raft and more #raft", now.Add(-2*time.Minute)))
+	test_search_service.IndexPost(newTestPost("3", "nothing to see here", now.Add(-1*time.Minute)))
+	test_search_service.IndexPost(newTestPost("4", "a post about etcd", now))
+
+	post_ids, total := test_search_service.SearchPosts("RAFT", nil, 0, 10)
+	if total != 2 || !reflect.DeepEqual(post_ids, []string{"2", "1"}) {
+		t.Errorf("Unexpected results for single term: %+v total %d\n", post_ids, total)
+	}
+
+	post_ids, total = test_search_service.SearchPosts("etcd raft", nil, 0, 10)
+	if total != 3 || post_ids[0] != "1" {
+		t.Errorf("Post matching every term not ranked first: %+v total %d\n", post_ids, total)
+	}
+
+	post_ids, total = test_search_service.SearchPosts("etcd raft", nil, 1, 1)
+	if total != 3 || len(post_ids) != 1 {
+		t.Errorf("Unexpected page: %+v total %d\n", post_ids, total)
+	}
+
+	test_search_service.RemovePost(&models.Post{PostID: "2"})
+	post_ids, total = test_search_service.SearchPosts("raft", nil, 0, 10)
+	if total != 1 || !reflect.DeepEqual(post_ids, []string{"1"}) {
+		t.Errorf("Removed post still returned: %+v total %d\n", post_ids, total)
+	}
+
+	post_ids, total = test_search_service.SearchPosts("!!", nil, 0, 10)
+	if total != 0 || len(post_ids) != 0 {
+		t.Errorf("Empty query returned results: %+v total %d\n", post_ids, total)
+	}
+}
+
+func TestSearchService_SearchUsers(t *testing.T) {
+	test_search_service := New()
+	for _, user_name := range []string{"alice", "alicia", "malice", "bob"} {
+		test_search_service.IndexUser(&models.User{UserName: user_name})
+	}
+	user_names, total := test_search_service.SearchUsers("ali", nil, 0, 10)
+	if total != 3 || !reflect.DeepEqual(user_names, []string{"alice", "alicia", "malice"}) {
+		t.Errorf("Unexpected user results: %+v total %d\n", user_names, total)
+	}
+	user_names, _ = test_search_service.SearchUsers("alice", nil, 0, 10)
+	if user_names[0] != "alice" {
+		t.Errorf("Exact username match not ranked first: %+v\n", user_names)
+	}
+	user_names, total = test_search_service.SearchUsers("ali", []string{"alicia"}, 0, 10)
+	if total != 2 || !reflect.DeepEqual(user_names, []string{"alice", "malice"}) {
+		t.Errorf("Excluded user returned: %+v total %d\n", user_names, total)
+	}
+}
+
+func TestSearchService_SearchPostsExcluded(t *testing.T) {
+	test_search_service := New()
+	now := time.Now()
+	test_search_service.IndexPost(newTestPost("1", "raft", now))
+	blocked_post := newTestPost("2", "raft", now)
+	blocked_post.PostedBy = "blocked"
+	test_search_service.IndexPost(blocked_post)
+	reply := newTestPost("3", "raft", now)
+	reply.ReplyToUser = "blocked"
+	test_search_service.IndexPost(reply)
+
+	post_ids, total := test_search_service.SearchPosts("raft", []string{"blocked"}, 0, 10)
+	if total != 1 || !reflect.DeepEqual(post_ids, []string{"1"}) {
+		t.Errorf("Posts of excluded user returned: %+v total %d\n", post_ids, total)
+	}
+}
+
+func TestSync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db := memory.New()
+	defer db.Close()
+	seeded_post, _ := db.PostStore().CreatePost(ctx, newTestPost("", "seeded raft", time.Now()))
+	test_search_service := New()
+	follow, err := Sync(ctx, test_search_service, db)
+	if err != nil {
+		t.Fatalf("Error in seeding the index: %+v\n", err)
+	}
+	go follow()
+
+	// the posts and users of another server only get to the index through the storage
+	db.UserStore().AddUser(ctx, &models.User{UserName: "raftfan"})
+	created_post, _ := db.PostStore().CreatePost(ctx, newTestPost("", "raft elsewhere", time.Now()))
+	db.PostStore().CreatePost(ctx, &models.Post{PostedBy: "test1", RepostOf: created_post.PostID})
+	db.PostStore().DeletePost(ctx, seeded_post)
+	deadline := time.Now().Add(time.Second)
+	for {
+		post_ids, total := test_search_service.SearchPosts("raft", nil, 0, 10)
+		user_names, _ := test_search_service.SearchUsers("raft", nil, 0, 10)
+		if total == 1 && post_ids[0] == created_post.PostID && len(user_names) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Index not kept current: %+v %+v\n", post_ids, user_names)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,40 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func (u *userStore) BlockUser(ctx context.Context, userName string, userToBlock string) error {
+	if _, userExistsError := u.GetUser(ctx, userName); userExistsError != nil {
+		return userExistsError
+	}
+	if _, userExistsError := u.GetUser(ctx, userToBlock); userExistsError != nil {
+		return userExistsError
+	}
+	key := fmt.Sprintf("%s/%s/%s", u.blocksPrefix, userName, userToBlock)
+	_, err := u.client.Put(ctx, key, "")
+	return err
+}
+
+func (u *userStore) UnBlockUser(ctx context.Context, userName string, userToUnBlock string) error {
+	key := fmt.Sprintf("%s/%s/%s", u.blocksPrefix, userName, userToUnBlock)
+	_, err := u.client.Delete(ctx, key)
+	return err
+}
+
+func (u *userStore) GetBlockedUsers(ctx context.Context, userName string) ([]string, error) {
+	prefix := fmt.Sprintf("%s/%s/", u.blocksPrefix, userName)
+	resp, err := u.client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+	blocked := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		blocked = append(blocked, strings.TrimPrefix(string(kv.Key), prefix))
+	}
+	return blocked, nil
+}
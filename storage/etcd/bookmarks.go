@@ -0,0 +1,119 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// the default limit of operations in a single etcd transaction
+const maxTxnOps = 128
+
+// bookmarks are stored twice, under the user to list them and under the post so that
+// deleting the post can find every bookmark of it
+type bookmarkStore struct {
+	client             *clientv3.Client
+	bookmarksPrefix    string
+	bookmarkedByPrefix string
+	postsPrefix        string
+}
+
+func (b *bookmarkStore) bookmarkKey(userName string, postId string) string {
+	return fmt.Sprintf("%s/%s/%s", b.bookmarksPrefix, userName, postId)
+}
+
+func (b *bookmarkStore) bookmarkedByKey(postId string, userName string) string {
+	return fmt.Sprintf("%s/%s/%s", b.bookmarkedByPrefix, postId, userName)
+}
+
+func (b *bookmarkStore) AddBookmark(ctx context.Context, userName string, postId string, bookmarkedAt time.Time) error {
+	postKey := fmt.Sprintf("%s/%s", b.postsPrefix, postId)
+	resp, err := b.client.Get(ctx, postKey)
+	if err != nil {
+		return err
+	}
+	if len(resp.Kvs) < 1 {
+		return errors.New("No matching posts found")
+	}
+	// bookmarks of an ephemeral post expire along with it
+	leaseId := clientv3.LeaseID(resp.Kvs[0].Lease)
+	txnResp, err := b.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.ModRevision(postKey), "=", resp.Kvs[0].ModRevision),
+	).Then(
+		clientv3.OpPut(b.bookmarkKey(userName, postId), strconv.FormatInt(bookmarkedAt.UnixNano(), 10), clientv3.WithLease(leaseId)),
+		clientv3.OpPut(b.bookmarkedByKey(postId, userName), "", clientv3.WithLease(leaseId)),
+	).Commit()
+	if err != nil {
+		return err
+	}
+	if !txnResp.Succeeded {
+		return errors.New("Post was modified or deleted while bookmarking")
+	}
+	return nil
+}
+
+func (b *bookmarkStore) RemoveBookmark(ctx context.Context, userName string, postId string) error {
+	_, err := b.client.Txn(ctx).Then(
+		clientv3.OpDelete(b.bookmarkKey(userName, postId)),
+		clientv3.OpDelete(b.bookmarkedByKey(postId, userName)),
+	).Commit()
+	return err
+}
+
+func (b *bookmarkStore) GetBookmarks(ctx context.Context, userName string) ([]string, error) {
+	prefix := fmt.Sprintf("%s/%s/", b.bookmarksPrefix, userName)
+	resp, err := b.client.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	postIds := make([]string, 0, len(resp.Kvs))
+	bookmarkedAt := make(map[string]int64, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		postId := strings.TrimPrefix(string(kv.Key), prefix)
+		nanos, err := strconv.ParseInt(string(kv.Value), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		postIds = append(postIds, postId)
+		bookmarkedAt[postId] = nanos
+	}
+	sort.Slice(postIds, func(i int, j int) bool {
+		if bookmarkedAt[postIds[i]] != bookmarkedAt[postIds[j]] {
+			return bookmarkedAt[postIds[i]] > bookmarkedAt[postIds[j]]
+		}
+		return postIds[i] < postIds[j]
+	})
+	return postIds, nil
+}
+
+func (b *bookmarkStore) RemovePost(ctx context.Context, postId string) error {
+	prefix := fmt.Sprintf("%s/%s/", b.bookmarkedByPrefix, postId)
+	resp, err := b.client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return err
+	}
+	ops := make([]clientv3.Op, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		userName := strings.TrimPrefix(string(kv.Key), prefix)
+		ops = append(ops, clientv3.OpDelete(b.bookmarkKey(userName, postId)))
+	}
+	// etcd limits the number of operations in a single transaction
+	for len(ops) > 0 {
+		batchSize := len(ops)
+		if batchSize > maxTxnOps {
+			batchSize = maxTxnOps
+		}
+		if _, err := b.client.Txn(ctx).Then(ops[:batchSize]...).Commit(); err != nil {
+			return err
+		}
+		ops = ops[batchSize:]
+	}
+	_, err = b.client.Delete(ctx, prefix, clientv3.WithPrefix())
+	return err
+}
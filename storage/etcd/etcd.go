@@ -2,21 +2,22 @@ package etcd
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
-	"log"
-	"os"
-	"os/signal"
+	"log/slog"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/twitter/logging"
 	"github.com/twitter/models"
 	"github.com/twitter/storage"
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -27,17 +28,31 @@ type userStore struct {
 	followersPrefix string
 	// users who the cur user follows
 	followsPrefix string
+	// id of the post the cur user pinned
+	pinnedPostsPrefix string
+	postsPrefix       string
+	// users who the cur user blocked
+	blocksPrefix string
 }
 
 type postStore struct {
-	client      *clientv3.Client
-	postsPrefix string
+	client          *clientv3.Client
+	postsPrefix     string
+	revisionsPrefix string
+	repliesPrefix   string
 }
 
 type etcd struct {
-	client *clientv3.Client
-	users  *userStore
-	posts  *postStore
+	client      *clientv3.Client
+	users       *userStore
+	posts       *postStore
+	hashtags    *hashtagStore
+	schedules   *scheduleStore
+	bookmarks   *bookmarkStore
+	polls       *pollStore
+	lists       *listStore
+	impressions *impressionStore
+	elector     *elector
 }
 
 func (e *etcd) UserStore() storage.UserStore {
@@ -48,16 +63,59 @@ func (e *etcd) PostStore() storage.PostStore {
 	return e.posts
 }
 
+func (e *etcd) HashtagStore() storage.HashtagStore {
+	return e.hashtags
+}
+
+func (e *etcd) ScheduleStore() storage.ScheduleStore {
+	return e.schedules
+}
+
+func (e *etcd) BookmarkStore() storage.BookmarkStore {
+	return e.bookmarks
+}
+
+func (e *etcd) PollStore() storage.PollStore {
+	return e.polls
+}
+
+func (e *etcd) ListStore() storage.ListStore {
+	return e.lists
+}
+
+func (e *etcd) ImpressionStore() storage.ImpressionStore {
+	return e.impressions
+}
+
+func (e *etcd) Elector() storage.Elector {
+	return e.elector
+}
+
+// Ping asks the endpoints for their status until one of them knows the elected leader of the cluster
+func (e *etcd) Ping(ctx context.Context) error {
+	err := storage.ErrNoLeader
+	for _, endpoint := range e.client.Endpoints() {
+		resp, statusErr := e.client.Status(ctx, endpoint)
+		if statusErr != nil {
+			err = statusErr
+			continue
+		}
+		if resp.Leader != 0 {
+			return nil
+		}
+	}
+	return err
+}
+
 func (e *etcd) Close() {
-	log.Println("closing etcd connection")
-	err := e.client.Close()
-	if err != nil {
-		log.Fatalln("Unable to close etcd connection")
+	slog.Info("Closing etcd connection")
+	if err := e.client.Close(); err != nil {
+		slog.Error("Unable to close etcd connection", "err", err)
 	}
 }
 
-func (u *userStore) AddUser(newUser *models.User) (*models.User, error) {
-	if _, userExistsError := u.GetUser(newUser.UserName); userExistsError == nil {
+func (u *userStore) AddUser(ctx context.Context, newUser *models.User) (*models.User, error) {
+	if _, userExistsError := u.GetUser(ctx, newUser.UserName); userExistsError == nil {
 		return nil, errors.New("User already exists")
 	}
 	userInBytes, err := proto.Marshal(newUser)
@@ -66,17 +124,17 @@ func (u *userStore) AddUser(newUser *models.User) (*models.User, error) {
 	}
 	stringifiedUser := string(userInBytes)
 	key := fmt.Sprintf("%s/%s/", u.userPrefix, newUser.UserName)
-	_, err = u.client.Put(context.Background(), key, stringifiedUser)
+	_, err = u.client.Put(ctx, key, stringifiedUser)
 	if err != nil {
 		return nil, err
 	}
 	return newUser, nil
 }
 
-func (u *userStore) GetUser(userName string) (*models.User, error) {
+func (u *userStore) GetUser(ctx context.Context, userName string) (*models.User, error) {
 
 	key := fmt.Sprintf("%s/%s/", u.userPrefix, userName)
-	resp, err := u.client.Get(context.Background(), key)
+	resp, err := u.client.Get(ctx, key)
 
 	if err != nil {
 		return nil, err
@@ -96,7 +154,7 @@ func (u *userStore) GetUser(userName string) (*models.User, error) {
 
 	keyPrefixForFollowing := fmt.Sprintf("%s/%s/", u.followsPrefix, userToReturn.UserName)
 
-	resp, err = u.client.Get(context.Background(), keyPrefixForFollowing, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	resp, err = u.client.Get(ctx, keyPrefixForFollowing, clientv3.WithPrefix(), clientv3.WithKeysOnly())
 
 	if err != nil {
 		return nil, err
@@ -109,7 +167,7 @@ func (u *userStore) GetUser(userName string) (*models.User, error) {
 
 	keyPrefixForFollowers := fmt.Sprintf("%s/%s/", u.followersPrefix, userToReturn.UserName)
 
-	resp, err = u.client.Get(context.Background(), keyPrefixForFollowers, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	resp, err = u.client.Get(ctx, keyPrefixForFollowers, clientv3.WithPrefix(), clientv3.WithKeysOnly())
 
 	if err != nil {
 		return nil, err
@@ -123,8 +181,35 @@ func (u *userStore) GetUser(userName string) (*models.User, error) {
 	return userToReturn, nil
 }
 
-func (u *userStore) UpdateUser(updatedUser *models.User) (*models.User, error) {
-	userInDB, userExistsError := u.GetUser(updatedUser.UserName)
+func (u *userStore) GetAllUsers(ctx context.Context) ([]*models.User, error) {
+	prefixKey := fmt.Sprintf("%s/", u.userPrefix)
+	resp, err := u.client.Get(ctx, prefixKey, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	usersToReturn := make([]*models.User, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		curUser := &models.User{}
+		if err := proto.Unmarshal(kv.Value, curUser); err != nil {
+			logging.FromContext(ctx).Warn("Skipping undecodable user", "key", string(kv.Key), "err", err)
+			continue
+		}
+		usersToReturn = append(usersToReturn, curUser)
+	}
+	return usersToReturn, nil
+}
+
+func (u *userStore) CountUsers(ctx context.Context) (int64, error) {
+	prefixKey := fmt.Sprintf("%s/", u.userPrefix)
+	resp, err := u.client.Get(ctx, prefixKey, clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+func (u *userStore) UpdateUser(ctx context.Context, updatedUser *models.User) (*models.User, error) {
+	userInDB, userExistsError := u.GetUser(ctx, updatedUser.UserName)
 
 	if userExistsError != nil {
 		return nil, userExistsError
@@ -144,7 +229,7 @@ func (u *userStore) UpdateUser(updatedUser *models.User) (*models.User, error) {
 	}
 	stringifiedUser := string(userInBytes)
 	key := fmt.Sprintf("%s/%s/", u.userPrefix, updatedUser.UserName)
-	_, err = u.client.Put(context.Background(), key, stringifiedUser)
+	_, err = u.client.Put(ctx, key, stringifiedUser)
 	if err != nil {
 		return nil, err
 	}
@@ -152,12 +237,12 @@ func (u *userStore) UpdateUser(updatedUser *models.User) (*models.User, error) {
 	return updatedUser, nil
 }
 
-func (u *userStore) FollowUser(curUser *models.User, userToFollow *models.User) error {
-	_, userExistsError := u.GetUser(curUser.UserName)
+func (u *userStore) FollowUser(ctx context.Context, curUser *models.User, userToFollow *models.User) error {
+	_, userExistsError := u.GetUser(ctx, curUser.UserName)
 	if userExistsError != nil {
 		return userExistsError
 	}
-	_, userExistsError = u.GetUser(userToFollow.UserName)
+	_, userExistsError = u.GetUser(ctx, userToFollow.UserName)
 	if userExistsError != nil {
 		return userExistsError
 	}
@@ -166,7 +251,7 @@ func (u *userStore) FollowUser(curUser *models.User, userToFollow *models.User)
 
 	key := fmt.Sprintf("%s/%s/%s", u.followsPrefix, curUser.UserName, userToFollow.UserName)
 
-	_, err := u.client.Put(context.Background(), key, "")
+	_, err := u.client.Put(ctx, key, "")
 
 	if err != nil {
 		return err
@@ -174,7 +259,7 @@ func (u *userStore) FollowUser(curUser *models.User, userToFollow *models.User)
 
 	key = fmt.Sprintf("%s/%s/%s", u.followersPrefix, userToFollow.UserName, curUser.UserName)
 
-	_, err = u.client.Put(context.Background(), key, "")
+	_, err = u.client.Put(ctx, key, "")
 
 	if err != nil {
 		return err
@@ -183,12 +268,12 @@ func (u *userStore) FollowUser(curUser *models.User, userToFollow *models.User)
 	return nil
 }
 
-func (u *userStore) UnFollowUser(curUser *models.User, userToUnFollow *models.User) error {
-	_, userExistsError := u.GetUser(curUser.UserName)
+func (u *userStore) UnFollowUser(ctx context.Context, curUser *models.User, userToUnFollow *models.User) error {
+	_, userExistsError := u.GetUser(ctx, curUser.UserName)
 	if userExistsError != nil {
 		return userExistsError
 	}
-	_, userExistsError = u.GetUser(userToUnFollow.UserName)
+	_, userExistsError = u.GetUser(ctx, userToUnFollow.UserName)
 	if userExistsError != nil {
 		return userExistsError
 	}
@@ -197,7 +282,7 @@ func (u *userStore) UnFollowUser(curUser *models.User, userToUnFollow *models.Us
 
 	key := fmt.Sprintf("%s/%s/%s", u.followsPrefix, curUser.UserName, userToUnFollow.UserName)
 
-	_, err := u.client.Delete(context.Background(), key)
+	_, err := u.client.Delete(ctx, key)
 
 	if err != nil {
 		return err
@@ -205,7 +290,7 @@ func (u *userStore) UnFollowUser(curUser *models.User, userToUnFollow *models.Us
 
 	key = fmt.Sprintf("%s/%s/%s", u.followersPrefix, userToUnFollow.UserName, curUser.UserName)
 
-	_, err = u.client.Delete(context.Background(), key)
+	_, err = u.client.Delete(ctx, key)
 
 	if err != nil {
 		return err
@@ -214,7 +299,7 @@ func (u *userStore) UnFollowUser(curUser *models.User, userToUnFollow *models.Us
 	return nil
 }
 
-func (p *postStore) CreatePost(newPost *models.Post) (*models.Post, error) {
+func (p *postStore) CreatePost(ctx context.Context, newPost *models.Post) (*models.Post, error) {
 	postId := uuid.New()
 	newPost.PostID = fmt.Sprintf("%s/%s", newPost.PostedBy, postId.String())
 
@@ -224,22 +309,30 @@ func (p *postStore) CreatePost(newPost *models.Post) (*models.Post, error) {
 		return nil, err
 	}
 	stringifiedPost := string(postInBytes)
-	_, err = p.client.Put(context.Background(), key, stringifiedPost)
+	leaseId, err := leaseUntil(ctx, p.client, newPost.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.client.Put(ctx, key, stringifiedPost, clientv3.WithLease(leaseId))
 	if err != nil {
 		return nil, err
 	}
 	return newPost, nil
 }
 
-func (p *postStore) DeletePost(postToDelete *models.Post) error {
+func (p *postStore) DeletePost(ctx context.Context, postToDelete *models.Post) error {
 	key := fmt.Sprintf("%s/%s", p.postsPrefix, postToDelete.PostID)
-	_, err := p.client.Delete(context.Background(), key)
+	revisionsKey := fmt.Sprintf("%s/%s/", p.revisionsPrefix, postToDelete.PostID)
+	_, err := p.client.Txn(ctx).Then(
+		clientv3.OpDelete(key),
+		clientv3.OpDelete(revisionsKey, clientv3.WithPrefix()),
+	).Commit()
 	return err
 }
 
-func (p *postStore) GetPosts(postedBy *models.User) ([]*models.Post, error) {
+func (p *postStore) GetPosts(ctx context.Context, postedBy *models.User) ([]*models.Post, error) {
 	prefixKey := fmt.Sprintf("%s/%s/", p.postsPrefix, postedBy.UserName)
-	resp, err := p.client.Get(context.Background(), prefixKey, clientv3.WithPrefix())
+	resp, err := p.client.Get(ctx, prefixKey, clientv3.WithPrefix())
 	if err != nil {
 		return nil, err
 	}
@@ -273,14 +366,14 @@ func (p *postStore) GetPosts(postedBy *models.User) ([]*models.Post, error) {
 	return postsToReturn, nil
 }
 
-func (p *postStore) GetPost(postId string) (*models.Post, error) {
+func (p *postStore) GetPost(ctx context.Context, postId string) (*models.Post, error) {
 	key := fmt.Sprintf("%s/%s", p.postsPrefix, postId)
-	resp, err := p.client.Get(context.Background(), key)
+	resp, err := p.client.Get(ctx, key)
 	if err != nil {
 		return nil, err
 	}
 	if len(resp.Kvs) < 1 {
-		return nil, errors.New("No matching posts found")
+		return nil, storage.ErrPostNotFound
 	}
 	postToReturn := &models.Post{}
 	err = proto.Unmarshal(resp.Kvs[0].Value, postToReturn)
@@ -290,37 +383,166 @@ func (p *postStore) GetPost(postId string) (*models.Post, error) {
 	return postToReturn, nil
 }
 
-func New(endpoints []string) (storage.Storage, error) {
-	newEtcd := &etcd{}
-	cli, err := clientv3.New(clientv3.Config{
-		Endpoints:   endpoints,
-		DialTimeout: 5 * time.Second,
-	})
+func (p *postStore) GetAllPosts(ctx context.Context) ([]*models.Post, error) {
+	prefixKey := fmt.Sprintf("%s/", p.postsPrefix)
+	resp, err := p.client.Get(ctx, prefixKey, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	postsToReturn := make([]*models.Post, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		curPost := &models.Post{}
+		if err := proto.Unmarshal(kv.Value, curPost); err != nil {
+			logging.FromContext(ctx).Warn("Skipping undecodable post", "key", string(kv.Key), "err", err)
+			continue
+		}
+		postsToReturn = append(postsToReturn, curPost)
+	}
+	return postsToReturn, nil
+}
+
+func (p *postStore) CountPosts(ctx context.Context) (int64, error) {
+	prefixKey := fmt.Sprintf("%s/", p.postsPrefix)
+	resp, err := p.client.Get(ctx, prefixKey, clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+func (p *postStore) EditPost(ctx context.Context, editedPost *models.Post, previous *models.PostRevision) (*models.Post, error) {
+	key := fmt.Sprintf("%s/%s", p.postsPrefix, editedPost.PostID)
+	// revisions are keyed by the time they were created so they range in order
+	revisionKey := fmt.Sprintf("%s/%s/%020d", p.revisionsPrefix, editedPost.PostID, previous.CreatedAt.AsTime().UnixNano())
+	postInBytes, err := proto.Marshal(editedPost)
+	if err != nil {
+		return nil, err
+	}
+	revisionInBytes, err := proto.Marshal(previous)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) < 1 {
+		return nil, errors.New("No matching posts found")
+	}
+	// the post and its revisions share the lease of an ephemeral post, so they expire together
+	leaseId := clientv3.LeaseID(resp.Kvs[0].Lease)
+	txnResp, err := p.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.ModRevision(key), "=", resp.Kvs[0].ModRevision),
+	).Then(
+		clientv3.OpPut(key, string(postInBytes), clientv3.WithLease(leaseId)),
+		clientv3.OpPut(revisionKey, string(revisionInBytes), clientv3.WithLease(leaseId)),
+	).Commit()
+	if err != nil {
+		return nil, err
+	}
+	if !txnResp.Succeeded {
+		return nil, errors.New("Post was modified or deleted while editing")
+	}
+	return editedPost, nil
+}
+
+func (p *postStore) GetPostRevisions(ctx context.Context, postId string) ([]*models.PostRevision, error) {
+	prefixKey := fmt.Sprintf("%s/%s/", p.revisionsPrefix, postId)
+	resp, err := p.client.Get(ctx, prefixKey, clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
 	if err != nil {
 		return nil, err
 	}
+	revisions := make([]*models.PostRevision, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		curRevision := &models.PostRevision{}
+		if err := proto.Unmarshal(kv.Value, curRevision); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, curRevision)
+	}
+	return revisions, nil
+}
+
+// New connects to the etcd cluster at the endpoints, over TLS when the config isn't nil
+func New(endpoints []string, tlsConfig *tls.Config) (storage.Storage, error) {
+	cli, err := NewClient(endpoints, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return FromClient(cli), nil
+}
+
+// NewClient connects to etcd the way the storage does, so that the servers coordinate over the storage's connection
+func NewClient(endpoints []string, tlsConfig *tls.Config) (*clientv3.Client, error) {
+	return clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+		TLS:         tlsConfig,
+		// chained so that the client keeps its own retrying interceptor, the calls to etcd are traced
+		// as children of the storage operation spans
+		DialOptions: []grpc.DialOption{
+			grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+			grpc.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+		},
+	})
+}
+
+// FromClient creates the storage on the client, closing the storage closes the client
+func FromClient(cli *clientv3.Client) storage.Storage {
+	newEtcd := &etcd{}
 	newEtcd.client = cli
 	newEtcd.posts = &postStore{
-		client:      cli,
-		postsPrefix: "twitter-key-posts",
+		client:          cli,
+		postsPrefix:     "twitter-key-posts",
+		revisionsPrefix: "twitter-key-post-revisions",
+		repliesPrefix:   "twitter-key-replies",
 	}
 	newEtcd.users = &userStore{
+		client:            cli,
+		userPrefix:        "twitter-key-users",
+		followersPrefix:   "twitter-key-followers",
+		followsPrefix:     "twitter-key-follows",
+		pinnedPostsPrefix: "twitter-key-pinned-posts",
+		blocksPrefix:      "twitter-key-blocks",
+		postsPrefix:       newEtcd.posts.postsPrefix,
+	}
+	newEtcd.hashtags = &hashtagStore{
+		client:         cli,
+		hashtagsPrefix: "twitter-key-hashtags",
+		trendsPrefix:   "twitter-key-trends",
+		bucketLeases:   make(map[int64]clientv3.LeaseID),
+	}
+	newEtcd.schedules = &scheduleStore{
+		client:          cli,
+		scheduledPrefix: "twitter-key-scheduled-posts",
+		postsPrefix:     newEtcd.posts.postsPrefix,
+	}
+	newEtcd.bookmarks = &bookmarkStore{
+		client:             cli,
+		bookmarksPrefix:    "twitter-key-bookmarks",
+		bookmarkedByPrefix: "twitter-key-bookmarked-by",
+		postsPrefix:        newEtcd.posts.postsPrefix,
+	}
+	newEtcd.polls = &pollStore{
+		client:       cli,
+		votersPrefix: "twitter-key-poll-voters",
+		votesPrefix:  "twitter-key-poll-votes",
+		postsPrefix:  newEtcd.posts.postsPrefix,
+	}
+	newEtcd.lists = &listStore{
 		client:          cli,
-		userPrefix:      "twitter-key-users",
-		followersPrefix: "twitter-key-followers",
-		followsPrefix:   "twitter-key-follows",
+		listsPrefix:     "twitter-key-lists",
+		membersPrefix:   "twitter-key-list-members",
+		userListsPrefix: "twitter-key-user-lists",
 	}
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, syscall.SIGTERM, syscall.SIGINT)
-		for {
-			s := <-c
-			if s == syscall.SIGTERM || s == syscall.SIGINT {
-				newEtcd.Close()
-				os.Exit(1)
-			}
-		}
-
-	}()
-	return newEtcd, nil
+	newEtcd.impressions = &impressionStore{
+		client:            cli,
+		impressionsPrefix: "twitter-key-impressions",
+		postsPrefix:       newEtcd.posts.postsPrefix,
+	}
+	newEtcd.elector = &elector{
+		client:         cli,
+		electionPrefix: "twitter-key-elections",
+	}
+	return newEtcd
 }
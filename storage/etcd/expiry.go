@@ -0,0 +1,60 @@
+package etcd
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/twitter/logging"
+	"github.com/twitter/models"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// leaseUntil grants a lease that runs out at the given time, or returns no lease if the time is nil
+func leaseUntil(ctx context.Context, client *clientv3.Client, expiresAt *timestamppb.Timestamp) (clientv3.LeaseID, error) {
+	if expiresAt == nil {
+		return clientv3.NoLease, nil
+	}
+	ttl := int64(math.Ceil(time.Until(expiresAt.AsTime()).Seconds()))
+	if ttl < 1 {
+		ttl = 1
+	}
+	lease, err := client.Grant(ctx, ttl)
+	if err != nil {
+		return clientv3.NoLease, err
+	}
+	return lease.ID, nil
+}
+
+func (p *postStore) WatchExpiredPosts(ctx context.Context) <-chan *models.Post {
+	expiredPosts := make(chan *models.Post)
+	go func() {
+		defer close(expiredPosts)
+		for ctx.Err() == nil {
+			// the watch is restarted if it fails, for example after the revision it was at got compacted
+			watchChan := p.client.Watch(clientv3.WithRequireLeader(ctx), p.postsPrefix+"/", clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithFilterPut())
+			for watchResp := range watchChan {
+				if err := watchResp.Err(); err != nil && ctx.Err() == nil {
+					logging.FromContext(ctx).Warn("Expired post watch failed, restarting it", "err", err)
+				}
+				for _, event := range watchResp.Events {
+					if event.PrevKv == nil || event.PrevKv.Lease == 0 {
+						continue
+					}
+					deletedPost := &models.Post{}
+					if err := proto.Unmarshal(event.PrevKv.Value, deletedPost); err != nil || deletedPost.ExpiresAt == nil {
+						continue
+					}
+					select {
+					case expiredPosts <- deletedPost:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return expiredPosts
+}
@@ -0,0 +1,130 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitter/models"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// size of the time buckets trend counters are grouped into
+	trendBucketSize = 5 * time.Minute
+	// how long a trend bucket lives before its lease expires
+	trendRetention = 7 * 24 * time.Hour
+)
+
+type hashtagStore struct {
+	client         *clientv3.Client
+	hashtagsPrefix string
+	trendsPrefix   string
+	// one lease per trend bucket, so that the whole bucket expires together
+	leaseMtx     sync.Mutex
+	bucketLeases map[int64]clientv3.LeaseID
+}
+
+func trendBucket(post *models.Post) int64 {
+	return post.PostedAt.AsTime().Truncate(trendBucketSize).Unix()
+}
+
+func (h *hashtagStore) trendKey(bucket int64, tag string, postId string) string {
+	return fmt.Sprintf("%s/%020d/%s/%s", h.trendsPrefix, bucket, tag, postId)
+}
+
+func (h *hashtagStore) getBucketLease(ctx context.Context, bucket int64) (clientv3.LeaseID, error) {
+	h.leaseMtx.Lock()
+	defer h.leaseMtx.Unlock()
+	if leaseId, exists := h.bucketLeases[bucket]; exists {
+		return leaseId, nil
+	}
+	bucketExpiry := time.Unix(bucket, 0).Add(trendBucketSize + trendRetention)
+	ttl := int64(time.Until(bucketExpiry).Seconds())
+	if ttl <= 0 {
+		return clientv3.NoLease, fmt.Errorf("trend bucket %d already expired", bucket)
+	}
+	lease, err := h.client.Grant(ctx, ttl)
+	if err != nil {
+		return clientv3.NoLease, err
+	}
+	oldestBucket := time.Now().Add(-trendRetention).Unix()
+	for curBucket := range h.bucketLeases {
+		if curBucket < oldestBucket {
+			delete(h.bucketLeases, curBucket)
+		}
+	}
+	h.bucketLeases[bucket] = lease.ID
+	return lease.ID, nil
+}
+
+func (h *hashtagStore) AddPost(ctx context.Context, tag string, post *models.Post) error {
+	// index entries of an ephemeral post expire along with the post
+	postLeaseId, err := leaseUntil(ctx, h.client, post.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%s/%s/%s", h.hashtagsPrefix, tag, post.PostID)
+	_, err = h.client.Put(ctx, key, "", clientv3.WithLease(postLeaseId))
+	if err != nil {
+		return err
+	}
+	bucket := trendBucket(post)
+	trendLeaseId := postLeaseId
+	if post.ExpiresAt == nil {
+		trendLeaseId, err = h.getBucketLease(ctx, bucket)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = h.client.Put(ctx, h.trendKey(bucket, tag, post.PostID), "", clientv3.WithLease(trendLeaseId))
+	return err
+}
+
+func (h *hashtagStore) RemovePost(ctx context.Context, tag string, post *models.Post) error {
+	key := fmt.Sprintf("%s/%s/%s", h.hashtagsPrefix, tag, post.PostID)
+	_, err := h.client.Txn(ctx).Then(
+		clientv3.OpDelete(key),
+		clientv3.OpDelete(h.trendKey(trendBucket(post), tag, post.PostID)),
+	).Commit()
+	return err
+}
+
+func (h *hashtagStore) GetPostIDs(ctx context.Context, tag string) ([]string, error) {
+	prefixKey := fmt.Sprintf("%s/%s/", h.hashtagsPrefix, tag)
+	resp, err := h.client.Get(ctx, prefixKey, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+	postIds := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		postIds = append(postIds, strings.TrimPrefix(string(kv.Key), prefixKey))
+	}
+	return postIds, nil
+}
+
+func (h *hashtagStore) GetTrendCounts(ctx context.Context, since time.Time) (map[string]int64, error) {
+	firstBucket := since.Truncate(trendBucketSize).Unix()
+	startKey := fmt.Sprintf("%s/%020d/", h.trendsPrefix, firstBucket)
+	endKey := clientv3.GetPrefixRangeEnd(h.trendsPrefix + "/")
+	resp, err := h.client.Get(ctx, startKey, clientv3.WithRange(endKey), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64)
+	for _, kv := range resp.Kvs {
+		// <bucket>/<tag>/<post id>
+		keyParts := strings.SplitN(strings.TrimPrefix(string(kv.Key), h.trendsPrefix+"/"), "/", 3)
+		if len(keyParts) != 3 {
+			continue
+		}
+		if _, err := strconv.ParseInt(keyParts[0], 10, 64); err != nil {
+			continue
+		}
+		counts[keyParts[1]] += 1
+	}
+	return counts, nil
+}
@@ -0,0 +1,138 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// the impressions of a post are spread over this many counters, so that concurrent writers of a
+	// popular post rarely update the same key
+	impressionShards = 16
+	// how many times adding impressions is retried on another shard when the counters changed meanwhile
+	maxImpressionRetries = 5
+)
+
+var errImpressionConflict = errors.New("Impression counters kept changing while adding impressions")
+
+type impressionStore struct {
+	client            *clientv3.Client
+	impressionsPrefix string
+	postsPrefix       string
+}
+
+func (i *impressionStore) shardKey(postId string, shard int) string {
+	return fmt.Sprintf("%s/%s/%02d", i.impressionsPrefix, postId, shard)
+}
+
+// AddImpressions commits the posts in batches, the posts of the batches committed before one fails are done. The
+// posts that don't exist are skipped so that their counters don't outlive them.
+func (i *impressionStore) AddImpressions(ctx context.Context, counts map[string]int64) ([]string, error) {
+	postIds := make([]string, 0, len(counts))
+	for postId := range counts {
+		postIds = append(postIds, postId)
+	}
+	sort.Strings(postIds)
+	done := make([]string, 0, len(postIds))
+	// two compares and a put per post, within etcd's limit of operations per transaction
+	batchSize := maxTxnOps / 3
+	for len(postIds) > 0 {
+		if batchSize > len(postIds) {
+			batchSize = len(postIds)
+		}
+		if err := i.addToShard(ctx, postIds[:batchSize], counts); err != nil {
+			return done, err
+		}
+		done = append(done, postIds[:batchSize]...)
+		postIds = postIds[batchSize:]
+	}
+	return done, nil
+}
+
+// addToShard increments the counters of one randomly picked shard of each of the existing posts, retrying on
+// another shard when one of the counters was updated or one of the posts deleted since they were read
+func (i *impressionStore) addToShard(ctx context.Context, postIds []string, counts map[string]int64) error {
+	for attempt := 0; attempt < maxImpressionRetries; attempt++ {
+		shard := rand.Intn(impressionShards)
+		getOps := make([]clientv3.Op, 0, 2*len(postIds))
+		for _, postId := range postIds {
+			getOps = append(getOps,
+				clientv3.OpGet(i.shardKey(postId, shard)),
+				clientv3.OpGet(fmt.Sprintf("%s/%s", i.postsPrefix, postId), clientv3.WithKeysOnly()))
+		}
+		resp, err := i.client.Txn(ctx).Then(getOps...).Commit()
+		if err != nil {
+			return err
+		}
+		compares := make([]clientv3.Cmp, 0, 2*len(postIds))
+		putOps := make([]clientv3.Op, 0, len(postIds))
+		for idx, postId := range postIds {
+			postKvs := resp.Responses[2*idx+1].GetResponseRange().Kvs
+			if len(postKvs) == 0 {
+				continue
+			}
+			postKey := fmt.Sprintf("%s/%s", i.postsPrefix, postId)
+			compares = append(compares, clientv3.Compare(clientv3.CreateRevision(postKey), "=", postKvs[0].CreateRevision))
+			key := i.shardKey(postId, shard)
+			current := int64(0)
+			modRevision := int64(0)
+			if kvs := resp.Responses[2*idx].GetResponseRange().Kvs; len(kvs) > 0 {
+				current, err = strconv.ParseInt(string(kvs[0].Value), 10, 64)
+				if err != nil {
+					return err
+				}
+				modRevision = kvs[0].ModRevision
+			}
+			compares = append(compares, clientv3.Compare(clientv3.ModRevision(key), "=", modRevision))
+			putOps = append(putOps, clientv3.OpPut(key, strconv.FormatInt(current+counts[postId], 10)))
+		}
+		txnResp, err := i.client.Txn(ctx).If(compares...).Then(putOps...).Commit()
+		if err != nil {
+			return err
+		}
+		if txnResp.Succeeded {
+			return nil
+		}
+	}
+	return errImpressionConflict
+}
+
+func (i *impressionStore) GetImpressions(ctx context.Context, postIds []string) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	for len(postIds) > 0 {
+		batchSize := len(postIds)
+		if batchSize > maxTxnOps {
+			batchSize = maxTxnOps
+		}
+		ops := make([]clientv3.Op, 0, batchSize)
+		for _, postId := range postIds[:batchSize] {
+			ops = append(ops, clientv3.OpGet(fmt.Sprintf("%s/%s/", i.impressionsPrefix, postId), clientv3.WithPrefix()))
+		}
+		resp, err := i.client.Txn(ctx).Then(ops...).Commit()
+		if err != nil {
+			return nil, err
+		}
+		for idx, opResp := range resp.Responses {
+			for _, kv := range opResp.GetResponseRange().Kvs {
+				count, err := strconv.ParseInt(string(kv.Value), 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				counts[postIds[idx]] += count
+			}
+		}
+		postIds = postIds[batchSize:]
+	}
+	return counts, nil
+}
+
+func (i *impressionStore) RemovePost(ctx context.Context, postId string) error {
+	_, err := i.client.Delete(ctx, fmt.Sprintf("%s/%s/", i.impressionsPrefix, postId), clientv3.WithPrefix())
+	return err
+}
@@ -0,0 +1,174 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/twitter/models"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// a list is stored without its members, every member has a key of its own so that adding
+// and removing members doesn't rewrite the list. Lists are also indexed by their owner.
+type listStore struct {
+	client          *clientv3.Client
+	listsPrefix     string
+	membersPrefix   string
+	userListsPrefix string
+}
+
+func (l *listStore) listKey(listId string) string {
+	return fmt.Sprintf("%s/%s", l.listsPrefix, listId)
+}
+
+func (l *listStore) memberKey(listId string, userName string) string {
+	return fmt.Sprintf("%s/%s/%s", l.membersPrefix, listId, userName)
+}
+
+func (l *listStore) userListKey(owner string, listId string) string {
+	return fmt.Sprintf("%s/%s/%s", l.userListsPrefix, owner, listId)
+}
+
+func (l *listStore) CreateList(ctx context.Context, newList *models.UserList) (*models.UserList, error) {
+	newList.ListID = uuid.New().String()
+	newList.Members = nil
+	listInBytes, err := proto.Marshal(newList)
+	if err != nil {
+		return nil, err
+	}
+	_, err = l.client.Txn(ctx).Then(
+		clientv3.OpPut(l.listKey(newList.ListID), string(listInBytes)),
+		clientv3.OpPut(l.userListKey(newList.Owner, newList.ListID), ""),
+	).Commit()
+	if err != nil {
+		return nil, err
+	}
+	return newList, nil
+}
+
+func (l *listStore) GetList(ctx context.Context, listId string) (*models.UserList, error) {
+	resp, err := l.client.Get(ctx, l.listKey(listId))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) < 1 {
+		return nil, errors.New("List doesn't exists")
+	}
+	list := &models.UserList{}
+	if err := proto.Unmarshal(resp.Kvs[0].Value, list); err != nil {
+		return nil, err
+	}
+	membersPrefix := fmt.Sprintf("%s/%s/", l.membersPrefix, listId)
+	// read the members at the revision of the list so both come from the same snapshot
+	resp, err = l.client.Get(ctx, membersPrefix, clientv3.WithPrefix(), clientv3.WithKeysOnly(), clientv3.WithRev(resp.Header.Revision))
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range resp.Kvs {
+		list.Members = append(list.Members, strings.TrimPrefix(string(kv.Key), membersPrefix))
+	}
+	return list, nil
+}
+
+func (l *listStore) GetLists(ctx context.Context, owner string) ([]*models.UserList, error) {
+	prefix := fmt.Sprintf("%s/%s/", l.userListsPrefix, owner)
+	resp, err := l.client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+	ownedLists := make([]*models.UserList, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		list, err := l.GetList(ctx, strings.TrimPrefix(string(kv.Key), prefix))
+		if err != nil {
+			// deleted after the index was read
+			continue
+		}
+		ownedLists = append(ownedLists, list)
+	}
+	sort.Slice(ownedLists, func(i int, j int) bool {
+		return ownedLists[i].CreatedAt.AsTime().Before(ownedLists[j].CreatedAt.AsTime())
+	})
+	return ownedLists, nil
+}
+
+func (l *listStore) UpdateList(ctx context.Context, updatedList *models.UserList) (*models.UserList, error) {
+	key := l.listKey(updatedList.ListID)
+	resp, err := l.client.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) < 1 {
+		return nil, errors.New("List doesn't exists")
+	}
+	list := &models.UserList{}
+	if err := proto.Unmarshal(resp.Kvs[0].Value, list); err != nil {
+		return nil, err
+	}
+	list.Name = updatedList.Name
+	list.Private = updatedList.Private
+	listInBytes, err := proto.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	txnResp, err := l.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.ModRevision(key), "=", resp.Kvs[0].ModRevision),
+	).Then(
+		clientv3.OpPut(key, string(listInBytes)),
+	).Commit()
+	if err != nil {
+		return nil, err
+	}
+	if !txnResp.Succeeded {
+		return nil, errors.New("List was modified or deleted while updating")
+	}
+	return l.GetList(ctx, list.ListID)
+}
+
+func (l *listStore) DeleteList(ctx context.Context, listId string) error {
+	list, err := l.GetList(ctx, listId)
+	if err != nil {
+		return err
+	}
+	_, err = l.client.Txn(ctx).Then(
+		clientv3.OpDelete(l.listKey(listId)),
+		clientv3.OpDelete(l.userListKey(list.Owner, listId)),
+		clientv3.OpDelete(fmt.Sprintf("%s/%s/", l.membersPrefix, listId), clientv3.WithPrefix()),
+	).Commit()
+	return err
+}
+
+func (l *listStore) AddMember(ctx context.Context, listId string, userName string) error {
+	listKey := l.listKey(listId)
+	txnResp, err := l.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.Version(listKey), ">", 0),
+	).Then(
+		clientv3.OpPut(l.memberKey(listId, userName), ""),
+	).Commit()
+	if err != nil {
+		return err
+	}
+	if !txnResp.Succeeded {
+		return errors.New("List doesn't exists")
+	}
+	return nil
+}
+
+func (l *listStore) RemoveMember(ctx context.Context, listId string, userName string) error {
+	txnResp, err := l.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.Version(l.listKey(listId)), ">", 0),
+	).Then(
+		clientv3.OpDelete(l.memberKey(listId, userName)),
+	).Commit()
+	if err != nil {
+		return err
+	}
+	if !txnResp.Succeeded {
+		return errors.New("List doesn't exists")
+	}
+	return nil
+}
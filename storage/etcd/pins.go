@@ -0,0 +1,64 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func (u *userStore) pinnedPostKey(userName string) string {
+	return fmt.Sprintf("%s/%s", u.pinnedPostsPrefix, userName)
+}
+
+func (u *userStore) PinPost(ctx context.Context, userName string, postId string) error {
+	postKey := fmt.Sprintf("%s/%s", u.postsPrefix, postId)
+	resp, err := u.client.Get(ctx, postKey)
+	if err != nil {
+		return err
+	}
+	if len(resp.Kvs) < 1 {
+		return errors.New("No matching posts found")
+	}
+	// the pin of an ephemeral post expires along with it
+	leaseId := clientv3.LeaseID(resp.Kvs[0].Lease)
+	txnResp, err := u.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.ModRevision(postKey), "=", resp.Kvs[0].ModRevision),
+	).Then(
+		clientv3.OpPut(u.pinnedPostKey(userName), postId, clientv3.WithLease(leaseId)),
+	).Commit()
+	if err != nil {
+		return err
+	}
+	if !txnResp.Succeeded {
+		return errors.New("Post was modified or deleted while pinning")
+	}
+	return nil
+}
+
+func (u *userStore) UnpinPost(ctx context.Context, userName string, postId string) error {
+	key := u.pinnedPostKey(userName)
+	if postId == "" {
+		_, err := u.client.Delete(ctx, key)
+		return err
+	}
+	// only clear the pin if it still points at the post, the user may have pinned another one since
+	_, err := u.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.Value(key), "=", postId),
+	).Then(
+		clientv3.OpDelete(key),
+	).Commit()
+	return err
+}
+
+func (u *userStore) GetPinnedPost(ctx context.Context, userName string) (string, error) {
+	resp, err := u.client.Get(ctx, u.pinnedPostKey(userName))
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Kvs) < 1 {
+		return "", nil
+	}
+	return string(resp.Kvs[0].Value), nil
+}
@@ -0,0 +1,97 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/twitter/storage"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// every vote is stored twice, under the voter to enforce a single vote per user and
+// under the option so that the votes of an option can be counted without reading them
+type pollStore struct {
+	client       *clientv3.Client
+	votersPrefix string
+	votesPrefix  string
+	postsPrefix  string
+}
+
+func (p *pollStore) voterKey(postId string, userName string) string {
+	return fmt.Sprintf("%s/%s/%s", p.votersPrefix, postId, userName)
+}
+
+func (p *pollStore) voteKey(postId string, option int, userName string) string {
+	return fmt.Sprintf("%s/%s/%d/%s", p.votesPrefix, postId, option, userName)
+}
+
+func (p *pollStore) AddVote(ctx context.Context, postId string, userName string, option int) error {
+	postKey := fmt.Sprintf("%s/%s", p.postsPrefix, postId)
+	resp, err := p.client.Get(ctx, postKey)
+	if err != nil {
+		return err
+	}
+	if len(resp.Kvs) < 1 {
+		return errors.New("No matching posts found")
+	}
+	// votes of an ephemeral post expire along with it
+	leaseId := clientv3.LeaseID(resp.Kvs[0].Lease)
+	voterKey := p.voterKey(postId, userName)
+	txnResp, err := p.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.CreateRevision(voterKey), "=", 0),
+		clientv3.Compare(clientv3.CreateRevision(postKey), "=", resp.Kvs[0].CreateRevision),
+	).Then(
+		clientv3.OpPut(voterKey, strconv.Itoa(option), clientv3.WithLease(leaseId)),
+		clientv3.OpPut(p.voteKey(postId, option, userName), "", clientv3.WithLease(leaseId)),
+	).Else(
+		clientv3.OpGet(voterKey, clientv3.WithCountOnly()),
+	).Commit()
+	if err != nil {
+		return err
+	}
+	if !txnResp.Succeeded {
+		if txnResp.Responses[0].GetResponseRange().Count > 0 {
+			return storage.ErrAlreadyVoted
+		}
+		return errors.New("Post was deleted while voting")
+	}
+	return nil
+}
+
+func (p *pollStore) GetVote(ctx context.Context, postId string, userName string) (int, bool, error) {
+	resp, err := p.client.Get(ctx, p.voterKey(postId, userName))
+	if err != nil {
+		return 0, false, err
+	}
+	if len(resp.Kvs) < 1 {
+		return 0, false, nil
+	}
+	option, err := strconv.Atoi(string(resp.Kvs[0].Value))
+	if err != nil {
+		return 0, false, err
+	}
+	return option, true, nil
+}
+
+func (p *pollStore) GetTallies(ctx context.Context, postId string, numOptions int) ([]int64, error) {
+	tallies := make([]int64, numOptions)
+	for option := 0; option < numOptions; option++ {
+		prefix := fmt.Sprintf("%s/%s/%d/", p.votesPrefix, postId, option)
+		resp, err := p.client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+		if err != nil {
+			return nil, err
+		}
+		tallies[option] = resp.Count
+	}
+	return tallies, nil
+}
+
+func (p *pollStore) RemovePoll(ctx context.Context, postId string) error {
+	_, err := p.client.Txn(ctx).Then(
+		clientv3.OpDelete(fmt.Sprintf("%s/%s/", p.votersPrefix, postId), clientv3.WithPrefix()),
+		clientv3.OpDelete(fmt.Sprintf("%s/%s/", p.votesPrefix, postId), clientv3.WithPrefix()),
+	).Commit()
+	return err
+}
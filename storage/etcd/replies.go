@@ -0,0 +1,55 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twitter/models"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func (p *postStore) replyKey(reply *models.Post) string {
+	return fmt.Sprintf("%s/%s/%s", p.repliesPrefix, reply.ReplyTo, reply.PostID)
+}
+
+func (p *postStore) AddReply(ctx context.Context, reply *models.Post) error {
+	// the reply of an ephemeral post stops counting once the reply expires
+	leaseId, err := leaseUntil(ctx, p.client, reply.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	_, err = p.client.Put(ctx, p.replyKey(reply), "", clientv3.WithLease(leaseId))
+	return err
+}
+
+func (p *postStore) RemoveReply(ctx context.Context, reply *models.Post) error {
+	_, err := p.client.Delete(ctx, p.replyKey(reply))
+	return err
+}
+
+func (p *postStore) GetReplyCounts(ctx context.Context, postIds []string) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	// the counts are read in batches of transactions so that the feed costs a few round trips
+	for len(postIds) > 0 {
+		batchSize := len(postIds)
+		if batchSize > maxTxnOps {
+			batchSize = maxTxnOps
+		}
+		ops := make([]clientv3.Op, 0, batchSize)
+		for _, postId := range postIds[:batchSize] {
+			prefix := fmt.Sprintf("%s/%s/", p.repliesPrefix, postId)
+			ops = append(ops, clientv3.OpGet(prefix, clientv3.WithPrefix(), clientv3.WithCountOnly()))
+		}
+		resp, err := p.client.Txn(ctx).Then(ops...).Commit()
+		if err != nil {
+			return nil, err
+		}
+		for idx, opResp := range resp.Responses {
+			if count := opResp.GetResponseRange().Count; count > 0 {
+				counts[postIds[idx]] = count
+			}
+		}
+		postIds = postIds[batchSize:]
+	}
+	return counts, nil
+}
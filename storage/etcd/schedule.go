@@ -0,0 +1,124 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/twitter/models"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// a leader that stops renewing its session loses the election after this many seconds
+	electionSessionTTL = 10
+	resignTimeout      = 5 * time.Second
+)
+
+type scheduleStore struct {
+	client          *clientv3.Client
+	scheduledPrefix string
+	postsPrefix     string
+}
+
+type elector struct {
+	client         *clientv3.Client
+	electionPrefix string
+}
+
+// scheduled posts are keyed by their publish time so the due ones can be read with a single range
+func (s *scheduleStore) scheduledKey(scheduledPost *models.ScheduledPost) string {
+	return fmt.Sprintf("%s/%020d/%s", s.scheduledPrefix, scheduledPost.PublishAt.AsTime().UnixNano(), scheduledPost.ScheduleID)
+}
+
+func (s *scheduleStore) AddScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.ScheduledPost, error) {
+	scheduledPost.ScheduleID = uuid.New().String()
+	scheduledInBytes, err := proto.Marshal(scheduledPost)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.client.Put(ctx, s.scheduledKey(scheduledPost), string(scheduledInBytes))
+	if err != nil {
+		return nil, err
+	}
+	return scheduledPost, nil
+}
+
+func (s *scheduleStore) GetDueScheduledPosts(ctx context.Context, now time.Time) ([]*models.ScheduledPost, error) {
+	startKey := fmt.Sprintf("%s/", s.scheduledPrefix)
+	// every key of a post due at or before now sorts before this one
+	endKey := fmt.Sprintf("%s/%020d0", s.scheduledPrefix, now.UnixNano())
+	resp, err := s.client.Get(ctx, startKey, clientv3.WithRange(endKey))
+	if err != nil {
+		return nil, err
+	}
+	duePosts := make([]*models.ScheduledPost, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		scheduledPost := &models.ScheduledPost{}
+		if err := proto.Unmarshal(kv.Value, scheduledPost); err != nil {
+			return nil, err
+		}
+		duePosts = append(duePosts, scheduledPost)
+	}
+	return duePosts, nil
+}
+
+func (s *scheduleStore) PublishScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.Post, error) {
+	newPost := scheduledPost.Post
+	newPost.PostID = fmt.Sprintf("%s/%s", newPost.PostedBy, uuid.New().String())
+	postInBytes, err := proto.Marshal(newPost)
+	if err != nil {
+		return nil, err
+	}
+	leaseId, err := leaseUntil(ctx, s.client, newPost.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	scheduledKey := s.scheduledKey(scheduledPost)
+	postKey := fmt.Sprintf("%s/%s", s.postsPrefix, newPost.PostID)
+	// removing the scheduled post and creating the post in one transaction makes sure
+	// the post is published exactly once, even if two publishers race each other
+	resp, err := s.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.Version(scheduledKey), ">", 0),
+	).Then(
+		clientv3.OpDelete(scheduledKey),
+		clientv3.OpPut(postKey, string(postInBytes), clientv3.WithLease(leaseId)),
+	).Commit()
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Succeeded {
+		return nil, nil
+	}
+	return newPost, nil
+}
+
+func (e *elector) Campaign(ctx context.Context, election string) (<-chan struct{}, error) {
+	session, err := concurrency.NewSession(e.client, concurrency.WithTTL(electionSessionTTL))
+	if err != nil {
+		return nil, err
+	}
+	etcdElection := concurrency.NewElection(session, fmt.Sprintf("%s/%s", e.electionPrefix, election))
+	hostname, _ := os.Hostname()
+	if err := etcdElection.Campaign(ctx, fmt.Sprintf("%s/%d", hostname, os.Getpid())); err != nil {
+		session.Close()
+		return nil, err
+	}
+	lost := make(chan struct{})
+	go func() {
+		defer close(lost)
+		select {
+		case <-ctx.Done():
+			resignCtx, cancel := context.WithTimeout(context.Background(), resignTimeout)
+			etcdElection.Resign(resignCtx)
+			cancel()
+			session.Close()
+		case <-session.Done():
+		}
+	}()
+	return lost, nil
+}
@@ -0,0 +1,88 @@
+package etcd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/twitter/logging"
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// watchPrefix hands every event under the prefix after the call to handle until ctx is done or handle returns false.
+// A failed watch is restarted after the last revision seen, the events are lost only if it got compacted meanwhile.
+func watchPrefix(ctx context.Context, client *clientv3.Client, prefix string, handle func(*clientv3.Event) bool) {
+	var nextRevision int64
+	for ctx.Err() == nil {
+		options := []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithPrevKV()}
+		if nextRevision > 0 {
+			options = append(options, clientv3.WithRev(nextRevision))
+		}
+		for watchResp := range client.Watch(clientv3.WithRequireLeader(ctx), prefix, options...) {
+			if err := watchResp.Err(); err != nil && ctx.Err() == nil {
+				logging.FromContext(ctx).Warn("Watch failed, restarting it", "prefix", prefix, "err", err)
+				if watchResp.CompactRevision > nextRevision {
+					nextRevision = watchResp.CompactRevision
+				}
+			}
+			for _, event := range watchResp.Events {
+				nextRevision = event.Kv.ModRevision + 1
+				if !handle(event) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (u *userStore) WatchUsers(ctx context.Context) <-chan *models.User {
+	changedUsers := make(chan *models.User)
+	go func() {
+		defer close(changedUsers)
+		watchPrefix(ctx, u.client, u.userPrefix+"/", func(event *clientv3.Event) bool {
+			if event.Type != mvccpb.PUT {
+				return true
+			}
+			changedUser := &models.User{}
+			if err := proto.Unmarshal(event.Kv.Value, changedUser); err != nil {
+				return true
+			}
+			select {
+			case changedUsers <- changedUser:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return changedUsers
+}
+
+func (p *postStore) WatchPosts(ctx context.Context) <-chan storage.PostEvent {
+	postEvents := make(chan storage.PostEvent)
+	go func() {
+		defer close(postEvents)
+		watchPrefix(ctx, p.client, p.postsPrefix+"/", func(event *clientv3.Event) bool {
+			postEvent := storage.PostEvent{Post: &models.Post{}, Deleted: event.Type == mvccpb.DELETE}
+			if postEvent.Deleted {
+				// the id is all there is to a removed post whose previous value got compacted
+				postEvent.Post.PostID = strings.TrimPrefix(string(event.Kv.Key), p.postsPrefix+"/")
+				if event.PrevKv != nil {
+					proto.Unmarshal(event.PrevKv.Value, postEvent.Post)
+				}
+			} else if err := proto.Unmarshal(event.Kv.Value, postEvent.Post); err != nil {
+				return true
+			}
+			select {
+			case postEvents <- postEvent:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return postEvents
+}
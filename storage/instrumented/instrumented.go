@@ -0,0 +1,123 @@
+// Package instrumented decorates a storage backend with prometheus metrics of the latency and errors of
+// every storage operation
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twitter/storage"
+)
+
+type storageMetrics struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+// operations reports the operations of one of the stores
+type operations struct {
+	store   string
+	metrics *storageMetrics
+}
+
+func (o operations) observe(operation string, start time.Time, err error) {
+	o.metrics.duration.WithLabelValues(o.store, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		o.metrics.errors.WithLabelValues(o.store, operation).Inc()
+	}
+}
+
+type instrumented struct {
+	db          storage.Storage
+	users       *userStore
+	posts       *postStore
+	hashtags    *hashtagStore
+	schedules   *scheduleStore
+	bookmarks   *bookmarkStore
+	polls       *pollStore
+	lists       *listStore
+	impressions *impressionStore
+}
+
+func (i *instrumented) UserStore() storage.UserStore {
+	return i.users
+}
+
+func (i *instrumented) PostStore() storage.PostStore {
+	return i.posts
+}
+
+func (i *instrumented) HashtagStore() storage.HashtagStore {
+	return i.hashtags
+}
+
+func (i *instrumented) ScheduleStore() storage.ScheduleStore {
+	return i.schedules
+}
+
+func (i *instrumented) BookmarkStore() storage.BookmarkStore {
+	return i.bookmarks
+}
+
+func (i *instrumented) PollStore() storage.PollStore {
+	return i.polls
+}
+
+func (i *instrumented) ListStore() storage.ListStore {
+	return i.lists
+}
+
+func (i *instrumented) ImpressionStore() storage.ImpressionStore {
+	return i.impressions
+}
+
+// Elector isn't instrumented, campaigning blocks for as long as the election takes
+func (i *instrumented) Elector() storage.Elector {
+	return i.db.Elector()
+}
+
+// Ping isn't instrumented, it's polled by the health checks rather than serving requests
+func (i *instrumented) Ping(ctx context.Context) error {
+	return i.db.Ping(ctx)
+}
+
+func (i *instrumented) Close() {
+	i.db.Close()
+}
+
+// New wraps the storage so that every operation is reported to the registerer
+func New(db storage.Storage, registerer prometheus.Registerer) (storage.Storage, error) {
+	metrics := &storageMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "twitter",
+			Subsystem: "storage",
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of storage operations.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"store", "operation"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "twitter",
+			Subsystem: "storage",
+			Name:      "operation_errors_total",
+			Help:      "Number of storage operations that returned an error.",
+		}, []string{"store", "operation"}),
+	}
+	if err := registerer.Register(metrics.duration); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(metrics.errors); err != nil {
+		return nil, err
+	}
+	return &instrumented{
+		db:          db,
+		users:       &userStore{operations{"users", metrics}, db.UserStore()},
+		posts:       &postStore{operations{"posts", metrics}, db.PostStore()},
+		hashtags:    &hashtagStore{operations{"hashtags", metrics}, db.HashtagStore()},
+		schedules:   &scheduleStore{operations{"schedules", metrics}, db.ScheduleStore()},
+		bookmarks:   &bookmarkStore{operations{"bookmarks", metrics}, db.BookmarkStore()},
+		polls:       &pollStore{operations{"polls", metrics}, db.PollStore()},
+		lists:       &listStore{operations{"lists", metrics}, db.ListStore()},
+		impressions: &impressionStore{operations{"impressions", metrics}, db.ImpressionStore()},
+	}, nil
+}
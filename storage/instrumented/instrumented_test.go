@@ -0,0 +1,46 @@
+package instrumented
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/twitter/models"
+	"github.com/twitter/storage/memory"
+)
+
+func TestInstrumented(t *testing.T) {
+	ctx := context.Background()
+	registry := prometheus.NewRegistry()
+	test_storage, err := New(memory.New(), registry)
+	if err != nil {
+		t.Fatalf("Error in instrumenting storage: %+v\n", err)
+	}
+	defer test_storage.Close()
+
+	test_storage.UserStore().AddUser(ctx, &models.User{UserName: "test1"})
+	if _, err := test_storage.UserStore().AddUser(ctx, &models.User{UserName: "test1"}); err == nil {
+		t.Error("Able to add a duplicate user through the instrumented storage")
+	}
+	created_post, err := test_storage.PostStore().CreatePost(ctx, &models.Post{PostedBy: "test1", Content: "post"})
+	if err != nil || created_post.PostID == "" {
+		t.Errorf("Post not created through the instrumented storage: %+v %+v\n", created_post, err)
+	}
+
+	instruments := test_storage.(*instrumented)
+	// one series for AddUser of the user store and one for CreatePost of the post store
+	if count := testutil.CollectAndCount(instruments.posts.metrics.duration); count != 2 {
+		t.Errorf("Unexpected number of latency series: %+v\n", count)
+	}
+	if errors := testutil.ToFloat64(instruments.users.metrics.errors.WithLabelValues("users", "AddUser")); errors != 1 {
+		t.Errorf("Unexpected number of AddUser errors: %+v\n", errors)
+	}
+	if errors := testutil.ToFloat64(instruments.posts.metrics.errors.WithLabelValues("posts", "CreatePost")); errors != 0 {
+		t.Errorf("Unexpected number of CreatePost errors: %+v\n", errors)
+	}
+
+	if _, err := New(memory.New(), registry); err == nil {
+		t.Error("Able to register the storage metrics twice")
+	}
+}
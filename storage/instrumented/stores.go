@@ -0,0 +1,404 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+)
+
+type userStore struct {
+	operations
+	store storage.UserStore
+}
+
+type postStore struct {
+	operations
+	store storage.PostStore
+}
+
+type hashtagStore struct {
+	operations
+	store storage.HashtagStore
+}
+
+type scheduleStore struct {
+	operations
+	store storage.ScheduleStore
+}
+
+type bookmarkStore struct {
+	operations
+	store storage.BookmarkStore
+}
+
+type pollStore struct {
+	operations
+	store storage.PollStore
+}
+
+type listStore struct {
+	operations
+	store storage.ListStore
+}
+
+type impressionStore struct {
+	operations
+	store storage.ImpressionStore
+}
+
+func (u *userStore) AddUser(ctx context.Context, newUser *models.User) (*models.User, error) {
+	start := time.Now()
+	result, err := u.store.AddUser(ctx, newUser)
+	u.observe("AddUser", start, err)
+	return result, err
+}
+
+func (u *userStore) GetUser(ctx context.Context, userName string) (*models.User, error) {
+	start := time.Now()
+	result, err := u.store.GetUser(ctx, userName)
+	u.observe("GetUser", start, err)
+	return result, err
+}
+
+func (u *userStore) GetAllUsers(ctx context.Context) ([]*models.User, error) {
+	start := time.Now()
+	result, err := u.store.GetAllUsers(ctx)
+	u.observe("GetAllUsers", start, err)
+	return result, err
+}
+
+func (u *userStore) CountUsers(ctx context.Context) (int64, error) {
+	start := time.Now()
+	result, err := u.store.CountUsers(ctx)
+	u.observe("CountUsers", start, err)
+	return result, err
+}
+
+func (u *userStore) UpdateUser(ctx context.Context, updatedUser *models.User) (*models.User, error) {
+	start := time.Now()
+	result, err := u.store.UpdateUser(ctx, updatedUser)
+	u.observe("UpdateUser", start, err)
+	return result, err
+}
+
+func (u *userStore) FollowUser(ctx context.Context, curUser *models.User, userToFollow *models.User) error {
+	start := time.Now()
+	err := u.store.FollowUser(ctx, curUser, userToFollow)
+	u.observe("FollowUser", start, err)
+	return err
+}
+
+func (u *userStore) UnFollowUser(ctx context.Context, curUser *models.User, userToUnFollow *models.User) error {
+	start := time.Now()
+	err := u.store.UnFollowUser(ctx, curUser, userToUnFollow)
+	u.observe("UnFollowUser", start, err)
+	return err
+}
+
+func (u *userStore) PinPost(ctx context.Context, userName string, postId string) error {
+	start := time.Now()
+	err := u.store.PinPost(ctx, userName, postId)
+	u.observe("PinPost", start, err)
+	return err
+}
+
+func (u *userStore) UnpinPost(ctx context.Context, userName string, postId string) error {
+	start := time.Now()
+	err := u.store.UnpinPost(ctx, userName, postId)
+	u.observe("UnpinPost", start, err)
+	return err
+}
+
+func (u *userStore) GetPinnedPost(ctx context.Context, userName string) (string, error) {
+	start := time.Now()
+	result, err := u.store.GetPinnedPost(ctx, userName)
+	u.observe("GetPinnedPost", start, err)
+	return result, err
+}
+
+func (u *userStore) BlockUser(ctx context.Context, userName string, userToBlock string) error {
+	start := time.Now()
+	err := u.store.BlockUser(ctx, userName, userToBlock)
+	u.observe("BlockUser", start, err)
+	return err
+}
+
+func (u *userStore) UnBlockUser(ctx context.Context, userName string, userToUnBlock string) error {
+	start := time.Now()
+	err := u.store.UnBlockUser(ctx, userName, userToUnBlock)
+	u.observe("UnBlockUser", start, err)
+	return err
+}
+
+func (u *userStore) GetBlockedUsers(ctx context.Context, userName string) ([]string, error) {
+	start := time.Now()
+	result, err := u.store.GetBlockedUsers(ctx, userName)
+	u.observe("GetBlockedUsers", start, err)
+	return result, err
+}
+
+func (u *userStore) WatchUsers(ctx context.Context) <-chan *models.User {
+	return u.store.WatchUsers(ctx)
+}
+
+func (p *postStore) CreatePost(ctx context.Context, newPost *models.Post) (*models.Post, error) {
+	start := time.Now()
+	result, err := p.store.CreatePost(ctx, newPost)
+	p.observe("CreatePost", start, err)
+	return result, err
+}
+
+func (p *postStore) DeletePost(ctx context.Context, postToDelete *models.Post) error {
+	start := time.Now()
+	err := p.store.DeletePost(ctx, postToDelete)
+	p.observe("DeletePost", start, err)
+	return err
+}
+
+func (p *postStore) GetPosts(ctx context.Context, postedBy *models.User) ([]*models.Post, error) {
+	start := time.Now()
+	result, err := p.store.GetPosts(ctx, postedBy)
+	p.observe("GetPosts", start, err)
+	return result, err
+}
+
+func (p *postStore) GetPost(ctx context.Context, postId string) (*models.Post, error) {
+	start := time.Now()
+	result, err := p.store.GetPost(ctx, postId)
+	p.observe("GetPost", start, err)
+	return result, err
+}
+
+func (p *postStore) GetAllPosts(ctx context.Context) ([]*models.Post, error) {
+	start := time.Now()
+	result, err := p.store.GetAllPosts(ctx)
+	p.observe("GetAllPosts", start, err)
+	return result, err
+}
+
+func (p *postStore) CountPosts(ctx context.Context) (int64, error) {
+	start := time.Now()
+	result, err := p.store.CountPosts(ctx)
+	p.observe("CountPosts", start, err)
+	return result, err
+}
+
+func (p *postStore) EditPost(ctx context.Context, editedPost *models.Post, previous *models.PostRevision) (*models.Post, error) {
+	start := time.Now()
+	result, err := p.store.EditPost(ctx, editedPost, previous)
+	p.observe("EditPost", start, err)
+	return result, err
+}
+
+func (p *postStore) GetPostRevisions(ctx context.Context, postId string) ([]*models.PostRevision, error) {
+	start := time.Now()
+	result, err := p.store.GetPostRevisions(ctx, postId)
+	p.observe("GetPostRevisions", start, err)
+	return result, err
+}
+
+func (p *postStore) WatchExpiredPosts(ctx context.Context) <-chan *models.Post {
+	return p.store.WatchExpiredPosts(ctx)
+}
+
+func (p *postStore) WatchPosts(ctx context.Context) <-chan storage.PostEvent {
+	return p.store.WatchPosts(ctx)
+}
+
+func (p *postStore) AddReply(ctx context.Context, reply *models.Post) error {
+	start := time.Now()
+	err := p.store.AddReply(ctx, reply)
+	p.observe("AddReply", start, err)
+	return err
+}
+
+func (p *postStore) RemoveReply(ctx context.Context, reply *models.Post) error {
+	start := time.Now()
+	err := p.store.RemoveReply(ctx, reply)
+	p.observe("RemoveReply", start, err)
+	return err
+}
+
+func (p *postStore) GetReplyCounts(ctx context.Context, postIds []string) (map[string]int64, error) {
+	start := time.Now()
+	result, err := p.store.GetReplyCounts(ctx, postIds)
+	p.observe("GetReplyCounts", start, err)
+	return result, err
+}
+
+func (h *hashtagStore) AddPost(ctx context.Context, tag string, post *models.Post) error {
+	start := time.Now()
+	err := h.store.AddPost(ctx, tag, post)
+	h.observe("AddPost", start, err)
+	return err
+}
+
+func (h *hashtagStore) RemovePost(ctx context.Context, tag string, post *models.Post) error {
+	start := time.Now()
+	err := h.store.RemovePost(ctx, tag, post)
+	h.observe("RemovePost", start, err)
+	return err
+}
+
+func (h *hashtagStore) GetPostIDs(ctx context.Context, tag string) ([]string, error) {
+	start := time.Now()
+	result, err := h.store.GetPostIDs(ctx, tag)
+	h.observe("GetPostIDs", start, err)
+	return result, err
+}
+
+func (h *hashtagStore) GetTrendCounts(ctx context.Context, since time.Time) (map[string]int64, error) {
+	start := time.Now()
+	result, err := h.store.GetTrendCounts(ctx, since)
+	h.observe("GetTrendCounts", start, err)
+	return result, err
+}
+
+func (s *scheduleStore) AddScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.ScheduledPost, error) {
+	start := time.Now()
+	result, err := s.store.AddScheduledPost(ctx, scheduledPost)
+	s.observe("AddScheduledPost", start, err)
+	return result, err
+}
+
+func (s *scheduleStore) GetDueScheduledPosts(ctx context.Context, now time.Time) ([]*models.ScheduledPost, error) {
+	start := time.Now()
+	result, err := s.store.GetDueScheduledPosts(ctx, now)
+	s.observe("GetDueScheduledPosts", start, err)
+	return result, err
+}
+
+func (s *scheduleStore) PublishScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.Post, error) {
+	start := time.Now()
+	result, err := s.store.PublishScheduledPost(ctx, scheduledPost)
+	s.observe("PublishScheduledPost", start, err)
+	return result, err
+}
+
+func (b *bookmarkStore) AddBookmark(ctx context.Context, userName string, postId string, bookmarkedAt time.Time) error {
+	start := time.Now()
+	err := b.store.AddBookmark(ctx, userName, postId, bookmarkedAt)
+	b.observe("AddBookmark", start, err)
+	return err
+}
+
+func (b *bookmarkStore) RemoveBookmark(ctx context.Context, userName string, postId string) error {
+	start := time.Now()
+	err := b.store.RemoveBookmark(ctx, userName, postId)
+	b.observe("RemoveBookmark", start, err)
+	return err
+}
+
+func (b *bookmarkStore) GetBookmarks(ctx context.Context, userName string) ([]string, error) {
+	start := time.Now()
+	result, err := b.store.GetBookmarks(ctx, userName)
+	b.observe("GetBookmarks", start, err)
+	return result, err
+}
+
+func (b *bookmarkStore) RemovePost(ctx context.Context, postId string) error {
+	start := time.Now()
+	err := b.store.RemovePost(ctx, postId)
+	b.observe("RemovePost", start, err)
+	return err
+}
+
+func (p *pollStore) AddVote(ctx context.Context, postId string, userName string, option int) error {
+	start := time.Now()
+	err := p.store.AddVote(ctx, postId, userName, option)
+	p.observe("AddVote", start, err)
+	return err
+}
+
+func (p *pollStore) GetVote(ctx context.Context, postId string, userName string) (int, bool, error) {
+	start := time.Now()
+	option, voted, err := p.store.GetVote(ctx, postId, userName)
+	p.observe("GetVote", start, err)
+	return option, voted, err
+}
+
+func (p *pollStore) GetTallies(ctx context.Context, postId string, numOptions int) ([]int64, error) {
+	start := time.Now()
+	result, err := p.store.GetTallies(ctx, postId, numOptions)
+	p.observe("GetTallies", start, err)
+	return result, err
+}
+
+func (p *pollStore) RemovePoll(ctx context.Context, postId string) error {
+	start := time.Now()
+	err := p.store.RemovePoll(ctx, postId)
+	p.observe("RemovePoll", start, err)
+	return err
+}
+
+func (l *listStore) CreateList(ctx context.Context, newList *models.UserList) (*models.UserList, error) {
+	start := time.Now()
+	result, err := l.store.CreateList(ctx, newList)
+	l.observe("CreateList", start, err)
+	return result, err
+}
+
+func (l *listStore) GetList(ctx context.Context, listId string) (*models.UserList, error) {
+	start := time.Now()
+	result, err := l.store.GetList(ctx, listId)
+	l.observe("GetList", start, err)
+	return result, err
+}
+
+func (l *listStore) GetLists(ctx context.Context, owner string) ([]*models.UserList, error) {
+	start := time.Now()
+	result, err := l.store.GetLists(ctx, owner)
+	l.observe("GetLists", start, err)
+	return result, err
+}
+
+func (l *listStore) UpdateList(ctx context.Context, updatedList *models.UserList) (*models.UserList, error) {
+	start := time.Now()
+	result, err := l.store.UpdateList(ctx, updatedList)
+	l.observe("UpdateList", start, err)
+	return result, err
+}
+
+func (l *listStore) DeleteList(ctx context.Context, listId string) error {
+	start := time.Now()
+	err := l.store.DeleteList(ctx, listId)
+	l.observe("DeleteList", start, err)
+	return err
+}
+
+func (l *listStore) AddMember(ctx context.Context, listId string, userName string) error {
+	start := time.Now()
+	err := l.store.AddMember(ctx, listId, userName)
+	l.observe("AddMember", start, err)
+	return err
+}
+
+func (l *listStore) RemoveMember(ctx context.Context, listId string, userName string) error {
+	start := time.Now()
+	err := l.store.RemoveMember(ctx, listId, userName)
+	l.observe("RemoveMember", start, err)
+	return err
+}
+
+func (i *impressionStore) AddImpressions(ctx context.Context, counts map[string]int64) ([]string, error) {
+	start := time.Now()
+	result, err := i.store.AddImpressions(ctx, counts)
+	i.observe("AddImpressions", start, err)
+	return result, err
+}
+
+func (i *impressionStore) GetImpressions(ctx context.Context, postIds []string) (map[string]int64, error) {
+	start := time.Now()
+	result, err := i.store.GetImpressions(ctx, postIds)
+	i.observe("GetImpressions", start, err)
+	return result, err
+}
+
+func (i *impressionStore) RemovePost(ctx context.Context, postId string) error {
+	start := time.Now()
+	err := i.store.RemovePost(ctx, postId)
+	i.observe("RemovePost", start, err)
+	return err
+}
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"sort"
+)
+
+func (u *userStore) BlockUser(ctx context.Context, userName string, userToBlock string) error {
+	if _, userExistsError := u.GetUser(ctx, userName); userExistsError != nil {
+		return userExistsError
+	}
+	if _, userExistsError := u.GetUser(ctx, userToBlock); userExistsError != nil {
+		return userExistsError
+	}
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	if u.blockedUsers[userName] == nil {
+		u.blockedUsers[userName] = make(map[string]struct{})
+	}
+	u.blockedUsers[userName][userToBlock] = struct{}{}
+	return nil
+}
+
+func (u *userStore) UnBlockUser(ctx context.Context, userName string, userToUnBlock string) error {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	delete(u.blockedUsers[userName], userToUnBlock)
+	if len(u.blockedUsers[userName]) == 0 {
+		delete(u.blockedUsers, userName)
+	}
+	return nil
+}
+
+func (u *userStore) GetBlockedUsers(ctx context.Context, userName string) ([]string, error) {
+	u.mtx.RLock()
+	defer u.mtx.RUnlock()
+	blocked := make([]string, 0, len(u.blockedUsers[userName]))
+	for blockedUser := range u.blockedUsers[userName] {
+		blocked = append(blocked, blockedUser)
+	}
+	sort.Strings(blocked)
+	return blocked, nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type bookmarkStore struct {
+	mtx sync.Mutex
+	// username -> post id -> time it was bookmarked
+	userBookmarks map[string]map[string]time.Time
+	// post id -> set of usernames that bookmarked it
+	postBookmarkers map[string]map[string]struct{}
+	posts           *postStore
+}
+
+func (b *bookmarkStore) AddBookmark(ctx context.Context, userName string, postId string, bookmarkedAt time.Time) error {
+	if _, err := b.posts.GetPost(ctx, postId); err != nil {
+		return err
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.userBookmarks[userName] == nil {
+		b.userBookmarks[userName] = make(map[string]time.Time)
+	}
+	b.userBookmarks[userName][postId] = bookmarkedAt
+	if b.postBookmarkers[postId] == nil {
+		b.postBookmarkers[postId] = make(map[string]struct{})
+	}
+	b.postBookmarkers[postId][userName] = struct{}{}
+	return nil
+}
+
+func (b *bookmarkStore) RemoveBookmark(ctx context.Context, userName string, postId string) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.removeBookmark(userName, postId)
+	return nil
+}
+
+// removeBookmark expects the caller to hold the lock
+func (b *bookmarkStore) removeBookmark(userName string, postId string) {
+	delete(b.userBookmarks[userName], postId)
+	if len(b.userBookmarks[userName]) == 0 {
+		delete(b.userBookmarks, userName)
+	}
+	delete(b.postBookmarkers[postId], userName)
+	if len(b.postBookmarkers[postId]) == 0 {
+		delete(b.postBookmarkers, postId)
+	}
+}
+
+func (b *bookmarkStore) GetBookmarks(ctx context.Context, userName string) ([]string, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	postIds := make([]string, 0, len(b.userBookmarks[userName]))
+	for postId := range b.userBookmarks[userName] {
+		postIds = append(postIds, postId)
+	}
+	bookmarks := b.userBookmarks[userName]
+	sort.Slice(postIds, func(i int, j int) bool {
+		if !bookmarks[postIds[i]].Equal(bookmarks[postIds[j]]) {
+			return bookmarks[postIds[i]].After(bookmarks[postIds[j]])
+		}
+		return postIds[i] < postIds[j]
+	})
+	return postIds, nil
+}
+
+func (b *bookmarkStore) RemovePost(ctx context.Context, postId string) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	for userName := range b.postBookmarkers[postId] {
+		b.removeBookmark(userName, postId)
+	}
+	return nil
+}
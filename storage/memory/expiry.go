@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/twitter/models"
+)
+
+// how often the reaper looks for expired posts
+const reapInterval = time.Second
+
+type expiryWatchers struct {
+	mtx      sync.Mutex
+	watchers map[chan *models.Post]context.Context
+}
+
+func (p *postStore) WatchExpiredPosts(ctx context.Context) <-chan *models.Post {
+	expiredPosts := make(chan *models.Post)
+	p.expiry.mtx.Lock()
+	p.expiry.watchers[expiredPosts] = ctx
+	p.expiry.mtx.Unlock()
+	go func() {
+		<-ctx.Done()
+		p.expiry.mtx.Lock()
+		delete(p.expiry.watchers, expiredPosts)
+		close(expiredPosts)
+		p.expiry.mtx.Unlock()
+	}()
+	return expiredPosts
+}
+
+// getExpiredPosts returns every post whose ExpiresAt is not after now
+func (p *postStore) getExpiredPosts(now time.Time) []*models.Post {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	expiredPosts := make([]*models.Post, 0)
+	for _, curUserPosts := range p.userPost {
+		curUserPosts.userPostMtx.RLock()
+		for _, post := range curUserPosts.posts {
+			if post.ExpiresAt != nil && !post.ExpiresAt.AsTime().After(now) {
+				expiredPosts = append(expiredPosts, post)
+			}
+		}
+		curUserPosts.userPostMtx.RUnlock()
+	}
+	return expiredPosts
+}
+
+// reapExpiredPosts deletes the expired posts and hands every one of them to the watchers
+func (p *postStore) reapExpiredPosts(now time.Time) {
+	for _, expiredPost := range p.getExpiredPosts(now) {
+		if err := p.DeletePost(context.Background(), expiredPost); err != nil {
+			// already deleted by its author
+			continue
+		}
+		p.expiry.mtx.Lock()
+		for watcher, watcherCtx := range p.expiry.watchers {
+			select {
+			case watcher <- expiredPost:
+			case <-watcherCtx.Done():
+			}
+		}
+		p.expiry.mtx.Unlock()
+	}
+}
+
+func (p *postStore) runReaper(stop <-chan struct{}) {
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			p.reapExpiredPosts(now)
+		}
+	}
+}
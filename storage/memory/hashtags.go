@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/twitter/models"
+)
+
+const (
+	// size of the time buckets trend counters are grouped into
+	trendBucketSize = 5 * time.Minute
+	// buckets older than this are dropped
+	trendRetention = 7 * 24 * time.Hour
+)
+
+type hashtagStore struct {
+	mtx sync.RWMutex
+	// tag -> set of post ids
+	tagPosts map[string]map[string]struct{}
+	// bucket start (unix seconds) -> tag -> number of posts
+	trendBuckets map[int64]map[string]int64
+}
+
+func trendBucket(post *models.Post) int64 {
+	return post.PostedAt.AsTime().Truncate(trendBucketSize).Unix()
+}
+
+func (h *hashtagStore) AddPost(ctx context.Context, tag string, post *models.Post) error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if h.tagPosts[tag] == nil {
+		h.tagPosts[tag] = make(map[string]struct{})
+	}
+	h.tagPosts[tag][post.PostID] = struct{}{}
+
+	bucket := trendBucket(post)
+	if h.trendBuckets[bucket] == nil {
+		h.trendBuckets[bucket] = make(map[string]int64)
+	}
+	h.trendBuckets[bucket][tag] += 1
+
+	oldestBucket := time.Now().Add(-trendRetention).Unix()
+	for curBucket := range h.trendBuckets {
+		if curBucket < oldestBucket {
+			delete(h.trendBuckets, curBucket)
+		}
+	}
+	return nil
+}
+
+func (h *hashtagStore) RemovePost(ctx context.Context, tag string, post *models.Post) error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if _, indexed := h.tagPosts[tag][post.PostID]; !indexed {
+		return nil
+	}
+	delete(h.tagPosts[tag], post.PostID)
+	if len(h.tagPosts[tag]) == 0 {
+		delete(h.tagPosts, tag)
+	}
+
+	bucket := trendBucket(post)
+	if h.trendBuckets[bucket][tag] > 0 {
+		h.trendBuckets[bucket][tag] -= 1
+		if h.trendBuckets[bucket][tag] == 0 {
+			delete(h.trendBuckets[bucket], tag)
+		}
+	}
+	return nil
+}
+
+func (h *hashtagStore) GetPostIDs(ctx context.Context, tag string) ([]string, error) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+	postIds := make([]string, 0, len(h.tagPosts[tag]))
+	for postId := range h.tagPosts[tag] {
+		postIds = append(postIds, postId)
+	}
+	return postIds, nil
+}
+
+func (h *hashtagStore) GetTrendCounts(ctx context.Context, since time.Time) (map[string]int64, error) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+	firstBucket := since.Truncate(trendBucketSize).Unix()
+	counts := make(map[string]int64)
+	for curBucket, tagCounts := range h.trendBuckets {
+		if curBucket < firstBucket {
+			continue
+		}
+		for tag, count := range tagCounts {
+			counts[tag] += count
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+type impressionStore struct {
+	mtx sync.RWMutex
+	// post id -> number of times the post was seen
+	impressions map[string]int64
+	posts       *postStore
+}
+
+func (i *impressionStore) AddImpressions(ctx context.Context, counts map[string]int64) ([]string, error) {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+	done := make([]string, 0, len(counts))
+	for postId, count := range counts {
+		if _, err := i.posts.GetPost(ctx, postId); err == nil {
+			i.impressions[postId] += count
+		}
+		done = append(done, postId)
+	}
+	return done, nil
+}
+
+func (i *impressionStore) GetImpressions(ctx context.Context, postIds []string) (map[string]int64, error) {
+	i.mtx.RLock()
+	defer i.mtx.RUnlock()
+	counts := make(map[string]int64)
+	for _, postId := range postIds {
+		if count, seen := i.impressions[postId]; seen {
+			counts[postId] = count
+		}
+	}
+	return counts, nil
+}
+
+func (i *impressionStore) RemovePost(ctx context.Context, postId string) error {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+	delete(i.impressions, postId)
+	return nil
+}
@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/twitter/models"
+	"google.golang.org/protobuf/proto"
+)
+
+type listStore struct {
+	mtx sync.RWMutex
+	// list id -> list, members kept sorted
+	lists map[string]*models.UserList
+}
+
+func (l *listStore) CreateList(ctx context.Context, newList *models.UserList) (*models.UserList, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	newList.ListID = uuid.New().String()
+	newList.Members = nil
+	l.lists[newList.ListID] = proto.Clone(newList).(*models.UserList)
+	return newList, nil
+}
+
+func (l *listStore) GetList(ctx context.Context, listId string) (*models.UserList, error) {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	list, exists := l.lists[listId]
+	if !exists {
+		return nil, errors.New("List doesn't exists")
+	}
+	// lists are handed out as copies so that callers can't change them without the lock
+	return proto.Clone(list).(*models.UserList), nil
+}
+
+func (l *listStore) GetLists(ctx context.Context, owner string) ([]*models.UserList, error) {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	ownedLists := make([]*models.UserList, 0)
+	for _, list := range l.lists {
+		if list.Owner == owner {
+			ownedLists = append(ownedLists, proto.Clone(list).(*models.UserList))
+		}
+	}
+	sort.Slice(ownedLists, func(i int, j int) bool {
+		return ownedLists[i].CreatedAt.AsTime().Before(ownedLists[j].CreatedAt.AsTime())
+	})
+	return ownedLists, nil
+}
+
+func (l *listStore) UpdateList(ctx context.Context, updatedList *models.UserList) (*models.UserList, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	list, exists := l.lists[updatedList.ListID]
+	if !exists {
+		return nil, errors.New("List doesn't exists")
+	}
+	list.Name = updatedList.Name
+	list.Private = updatedList.Private
+	return proto.Clone(list).(*models.UserList), nil
+}
+
+func (l *listStore) DeleteList(ctx context.Context, listId string) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	delete(l.lists, listId)
+	return nil
+}
+
+func (l *listStore) AddMember(ctx context.Context, listId string, userName string) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	list, exists := l.lists[listId]
+	if !exists {
+		return errors.New("List doesn't exists")
+	}
+	idx := sort.SearchStrings(list.Members, userName)
+	if idx < len(list.Members) && list.Members[idx] == userName {
+		return nil
+	}
+	list.Members = append(list.Members, "")
+	copy(list.Members[idx+1:], list.Members[idx:])
+	list.Members[idx] = userName
+	return nil
+}
+
+func (l *listStore) RemoveMember(ctx context.Context, listId string, userName string) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	list, exists := l.lists[listId]
+	if !exists {
+		return errors.New("List doesn't exists")
+	}
+	idx := sort.SearchStrings(list.Members, userName)
+	if idx < len(list.Members) && list.Members[idx] == userName {
+		list.Members = append(list.Members[:idx], list.Members[idx+1:]...)
+	}
+	return nil
+}
@@ -1,9 +1,11 @@
 package memory
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/twitter/models"
 	"github.com/twitter/storage"
@@ -17,6 +19,11 @@ type threadSafeUser struct {
 type userStore struct {
 	mtx      sync.RWMutex
 	usersMap map[string]*threadSafeUser
+	// username -> id of the pinned post
+	pinnedPosts map[string]string
+	// username -> set of usernames blocked by the user
+	blockedUsers map[string]map[string]struct{}
+	changes      watchers[*models.User]
 }
 
 type userPostMap struct {
@@ -26,15 +33,30 @@ type userPostMap struct {
 }
 
 type postStore struct {
-	mtx         sync.RWMutex
-	postTillNow int64
-	userPost    map[string]*userPostMap
-	postUser    map[string]string
+	mtx           sync.RWMutex
+	postTillNow   int64
+	userPost      map[string]*userPostMap
+	postUser      map[string]string
+	postRevisions map[string][]*models.PostRevision
+	// post id -> set of the ids of its replies
+	replies map[string]map[string]struct{}
+	expiry  expiryWatchers
+	changes watchers[storage.PostEvent]
 }
 
 type memory struct {
-	users *userStore
-	posts *postStore
+	users       *userStore
+	posts       *postStore
+	hashtags    *hashtagStore
+	schedules   *scheduleStore
+	bookmarks   *bookmarkStore
+	polls       *pollStore
+	lists       *listStore
+	impressions *impressionStore
+	elector     *elector
+	// closed to stop the expired post reaper
+	stopReaper chan struct{}
+	closeOnce  sync.Once
 }
 
 func (m *memory) UserStore() storage.UserStore {
@@ -45,11 +67,47 @@ func (m *memory) PostStore() storage.PostStore {
 	return m.posts
 }
 
+func (m *memory) HashtagStore() storage.HashtagStore {
+	return m.hashtags
+}
+
+func (m *memory) ScheduleStore() storage.ScheduleStore {
+	return m.schedules
+}
+
+func (m *memory) BookmarkStore() storage.BookmarkStore {
+	return m.bookmarks
+}
+
+func (m *memory) PollStore() storage.PollStore {
+	return m.polls
+}
+
+func (m *memory) ListStore() storage.ListStore {
+	return m.lists
+}
+
+func (m *memory) ImpressionStore() storage.ImpressionStore {
+	return m.impressions
+}
+
+func (m *memory) Elector() storage.Elector {
+	return m.elector
+}
+
+// Ping always succeeds, the memory is always there
+func (m *memory) Ping(ctx context.Context) error {
+	return nil
+}
+
 func (m *memory) Close() {
+	m.closeOnce.Do(func() {
+		close(m.stopReaper)
+	})
 }
 
-func (u *userStore) AddUser(newUser *models.User) (*models.User, error) {
-	if _, userExistsError := u.GetUser(newUser.UserName); userExistsError == nil {
+func (u *userStore) addUser(ctx context.Context, newUser *models.User) (*models.User, error) {
+	if _, userExistsError := u.GetUser(ctx, newUser.UserName); userExistsError == nil {
 		return nil, errors.New("User already exists")
 	}
 	u.mtx.Lock()
@@ -65,7 +123,7 @@ func (u *userStore) AddUser(newUser *models.User) (*models.User, error) {
 	return u.usersMap[newUser.UserName].user, nil
 }
 
-func (u *userStore) GetUser(userName string) (*models.User, error) {
+func (u *userStore) GetUser(ctx context.Context, userName string) (*models.User, error) {
 	u.mtx.RLock()
 	userWithLock, exists := u.usersMap[userName]
 	u.mtx.RUnlock()
@@ -75,8 +133,24 @@ func (u *userStore) GetUser(userName string) (*models.User, error) {
 	return userWithLock.user, nil
 }
 
-func (u *userStore) UpdateUser(updatedUser *models.User) (*models.User, error) {
-	if _, userExistsError := u.GetUser(updatedUser.UserName); userExistsError != nil {
+func (u *userStore) GetAllUsers(ctx context.Context) ([]*models.User, error) {
+	u.mtx.RLock()
+	defer u.mtx.RUnlock()
+	usersToReturn := make([]*models.User, 0, len(u.usersMap))
+	for _, userWithLock := range u.usersMap {
+		usersToReturn = append(usersToReturn, userWithLock.user)
+	}
+	return usersToReturn, nil
+}
+
+func (u *userStore) CountUsers(ctx context.Context) (int64, error) {
+	u.mtx.RLock()
+	defer u.mtx.RUnlock()
+	return int64(len(u.usersMap)), nil
+}
+
+func (u *userStore) updateUser(ctx context.Context, updatedUser *models.User) (*models.User, error) {
+	if _, userExistsError := u.GetUser(ctx, updatedUser.UserName); userExistsError != nil {
 		return nil, userExistsError
 	}
 	u.mtx.Lock()
@@ -96,11 +170,11 @@ func (u *userStore) UpdateUser(updatedUser *models.User) (*models.User, error) {
 	return u.usersMap[updatedUser.UserName].user, nil
 }
 
-func (u *userStore) FollowUser(curUser *models.User, userToFollow *models.User) error {
-	if _, userExistsError := u.GetUser(curUser.UserName); userExistsError != nil {
+func (u *userStore) FollowUser(ctx context.Context, curUser *models.User, userToFollow *models.User) error {
+	if _, userExistsError := u.GetUser(ctx, curUser.UserName); userExistsError != nil {
 		return userExistsError
 	}
-	if _, userExistsError := u.GetUser(userToFollow.UserName); userExistsError != nil {
+	if _, userExistsError := u.GetUser(ctx, userToFollow.UserName); userExistsError != nil {
 		return userExistsError
 	}
 	u.mtx.Lock()
@@ -113,8 +187,13 @@ func (u *userStore) FollowUser(curUser *models.User, userToFollow *models.User)
 	}()
 	u.mtx.Unlock()
 
-	u.usersMap[curUser.UserName].user.Follows = append(u.usersMap[curUser.UserName].user.Follows, userToFollow.UserName)
-	u.usersMap[userToFollow.UserName].user.Followers = append(u.usersMap[userToFollow.UserName].user.Followers, curUser.UserName)
+	// following again changes nothing, as on etcd
+	if getIndexOfValue(u.usersMap[curUser.UserName].user.Follows, userToFollow.UserName) < 0 {
+		u.usersMap[curUser.UserName].user.Follows = append(u.usersMap[curUser.UserName].user.Follows, userToFollow.UserName)
+	}
+	if getIndexOfValue(u.usersMap[userToFollow.UserName].user.Followers, curUser.UserName) < 0 {
+		u.usersMap[userToFollow.UserName].user.Followers = append(u.usersMap[userToFollow.UserName].user.Followers, curUser.UserName)
+	}
 	return nil
 }
 
@@ -132,11 +211,11 @@ func remove(s []string, i int) []string {
 	return s[:len(s)-1]
 }
 
-func (u *userStore) UnFollowUser(curUser *models.User, userToUnFollow *models.User) error {
-	if _, userExistsError := u.GetUser(curUser.UserName); userExistsError != nil {
+func (u *userStore) UnFollowUser(ctx context.Context, curUser *models.User, userToUnFollow *models.User) error {
+	if _, userExistsError := u.GetUser(ctx, curUser.UserName); userExistsError != nil {
 		return userExistsError
 	}
-	if _, userExistsError := u.GetUser(userToUnFollow.UserName); userExistsError != nil {
+	if _, userExistsError := u.GetUser(ctx, userToUnFollow.UserName); userExistsError != nil {
 		return userExistsError
 	}
 	u.mtx.Lock()
@@ -159,7 +238,7 @@ func (u *userStore) UnFollowUser(curUser *models.User, userToUnFollow *models.Us
 	return nil
 }
 
-func (p *postStore) CreatePost(newPost *models.Post) (*models.Post, error) {
+func (p *postStore) createPost(ctx context.Context, newPost *models.Post) (*models.Post, error) {
 	p.mtx.Lock()
 	if p.userPost[newPost.PostedBy] == nil {
 		curUserPostMap := &userPostMap{}
@@ -188,25 +267,25 @@ func (p *postStore) CreatePost(newPost *models.Post) (*models.Post, error) {
 	return newPost, nil
 }
 
-func (p *postStore) DeletePost(postToDelete *models.Post) error {
+func (p *postStore) deletePost(ctx context.Context, postToDelete *models.Post) error {
 	p.mtx.Lock()
 	if p.userPost[postToDelete.PostedBy] == nil {
 		p.mtx.Unlock()
 		return errors.New("Post does not exists")
 	}
 	delete(p.postUser, postToDelete.PostID)
+	delete(p.postRevisions, postToDelete.PostID)
 	p.userPost[postToDelete.PostedBy].userPostMtx.Lock()
 	defer p.userPost[postToDelete.PostedBy].userPostMtx.Unlock()
 	p.mtx.Unlock()
 	if _, postExists := p.userPost[postToDelete.PostedBy].posts[postToDelete.PostID]; !postExists {
-		p.userPost[postToDelete.PostedBy].userPostMtx.Unlock()
 		return errors.New("Post does not exists")
 	}
 	delete(p.userPost[postToDelete.PostedBy].posts, postToDelete.PostID)
 	return nil
 }
 
-func (p *postStore) GetPosts(postedBy *models.User) ([]*models.Post, error) {
+func (p *postStore) GetPosts(ctx context.Context, postedBy *models.User) ([]*models.Post, error) {
 	p.mtx.RLock()
 	if p.userPost[postedBy.UserName] == nil {
 		p.mtx.RUnlock()
@@ -242,30 +321,107 @@ func (p *postStore) GetPosts(postedBy *models.User) ([]*models.Post, error) {
 	return postsToReturn, nil
 }
 
-func (p *postStore) GetPost(postId string) (*models.Post, error) {
+func (p *postStore) GetPost(ctx context.Context, postId string) (*models.Post, error) {
 	p.mtx.RLock()
 	createdBy, postExists := p.postUser[postId]
 	if !postExists {
-		return nil, errors.New("Post doesn't exists")
+		p.mtx.RUnlock()
+		return nil, storage.ErrPostNotFound
 	}
 	p.userPost[createdBy].userPostMtx.RLock()
 	p.mtx.RUnlock()
 	defer p.userPost[createdBy].userPostMtx.RUnlock()
 	postToReturn, postExists := p.userPost[createdBy].posts[postId]
 	if !postExists {
-		return nil, errors.New("Post doesn't exists")
+		return nil, storage.ErrPostNotFound
 	}
 	return postToReturn, nil
 }
 
+func (p *postStore) GetAllPosts(ctx context.Context) ([]*models.Post, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	postsToReturn := make([]*models.Post, 0, len(p.postUser))
+	for _, curUserPosts := range p.userPost {
+		curUserPosts.userPostMtx.RLock()
+		for _, val := range curUserPosts.posts {
+			postsToReturn = append(postsToReturn, val)
+		}
+		curUserPosts.userPostMtx.RUnlock()
+	}
+	return postsToReturn, nil
+}
+
+func (p *postStore) CountPosts(ctx context.Context) (int64, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	return int64(len(p.postUser)), nil
+}
+
+func (p *postStore) editPost(ctx context.Context, editedPost *models.Post, previous *models.PostRevision) (*models.Post, error) {
+	p.mtx.Lock()
+	createdBy, postExists := p.postUser[editedPost.PostID]
+	if !postExists {
+		p.mtx.Unlock()
+		return nil, errors.New("Post doesn't exists")
+	}
+	p.postRevisions[editedPost.PostID] = append(p.postRevisions[editedPost.PostID], previous)
+	p.userPost[createdBy].userPostMtx.Lock()
+	defer p.userPost[createdBy].userPostMtx.Unlock()
+	p.mtx.Unlock()
+	p.userPost[createdBy].posts[editedPost.PostID] = editedPost
+	return editedPost, nil
+}
+
+func (p *postStore) GetPostRevisions(ctx context.Context, postId string) ([]*models.PostRevision, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	revisions := make([]*models.PostRevision, len(p.postRevisions[postId]))
+	copy(revisions, p.postRevisions[postId])
+	return revisions, nil
+}
+
 func New() storage.Storage {
 	m := &memory{}
 	m.users = &userStore{}
 	m.users.usersMap = make(map[string]*threadSafeUser)
+	m.users.pinnedPosts = make(map[string]string)
+	m.users.blockedUsers = make(map[string]map[string]struct{})
+	m.users.changes.channels = make(map[chan *models.User]context.Context)
 	m.posts = &postStore{
 		postTillNow: 0,
 	}
 	m.posts.userPost = make(map[string]*userPostMap)
 	m.posts.postUser = make(map[string]string)
+	m.posts.postRevisions = make(map[string][]*models.PostRevision)
+	m.posts.replies = make(map[string]map[string]struct{})
+	m.posts.expiry.watchers = make(map[chan *models.Post]context.Context)
+	m.posts.changes.channels = make(map[chan storage.PostEvent]context.Context)
+	m.hashtags = &hashtagStore{
+		tagPosts:     make(map[string]map[string]struct{}),
+		trendBuckets: make(map[int64]map[string]int64),
+	}
+	m.schedules = &scheduleStore{
+		scheduledPosts: make(map[string]*models.ScheduledPost),
+		posts:          m.posts,
+	}
+	m.bookmarks = &bookmarkStore{
+		userBookmarks:   make(map[string]map[string]time.Time),
+		postBookmarkers: make(map[string]map[string]struct{}),
+		posts:           m.posts,
+	}
+	m.polls = &pollStore{
+		votes: make(map[string]map[string]int),
+	}
+	m.lists = &listStore{
+		lists: make(map[string]*models.UserList),
+	}
+	m.impressions = &impressionStore{
+		impressions: make(map[string]int64),
+		posts:       m.posts,
+	}
+	m.elector = &elector{}
+	m.stopReaper = make(chan struct{})
+	go m.posts.runReaper(m.stopReaper)
 	return m
 }
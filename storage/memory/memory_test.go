@@ -1,19 +1,23 @@
 package memory
 
 import (
+	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/twitter/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func Test_userStore_AddUser(t *testing.T) {
+	ctx := context.Background()
 	test_user := &models.User{
 		UserName:     "test1",
 		UserPassword: "password1",
 	}
 	test_storage := New()
-	created_user, err := test_storage.UserStore().AddUser(test_user)
+	created_user, err := test_storage.UserStore().AddUser(ctx, test_user)
 	if err != nil {
 		t.Errorf("Error in adding user: %+v\n", err)
 	}
@@ -24,7 +28,7 @@ func Test_userStore_AddUser(t *testing.T) {
 		t.Error("Returned Users username doesn't match")
 	}
 
-	created_user_v2, err := test_storage.UserStore().AddUser(test_user)
+	created_user_v2, err := test_storage.UserStore().AddUser(ctx, test_user)
 
 	if err == nil {
 		t.Error("Error in adding user, able to add duplicate users")
@@ -38,12 +42,13 @@ func Test_userStore_AddUser(t *testing.T) {
 }
 
 func Test_userStore_GetUser(t *testing.T) {
+	ctx := context.Background()
 	test_storage := New()
 	test_user := &models.User{
 		UserName:     "test1",
 		UserPassword: "password1",
 	}
-	nil_user, err := test_storage.UserStore().GetUser(test_user.UserName)
+	nil_user, err := test_storage.UserStore().GetUser(ctx, test_user.UserName)
 
 	if err == nil {
 		t.Error("Error in get nil user, user was never created")
@@ -57,9 +62,9 @@ func Test_userStore_GetUser(t *testing.T) {
 		t.Errorf("Error in getting nil user, returned non nil user: %+v\n", nil_user)
 	}
 
-	test_storage.UserStore().AddUser(test_user)
+	test_storage.UserStore().AddUser(ctx, test_user)
 
-	retrieved_user, err := test_storage.UserStore().GetUser(test_user.UserName)
+	retrieved_user, err := test_storage.UserStore().GetUser(ctx, test_user.UserName)
 
 	if err != nil {
 		t.Errorf("Error in get user: %+v\n", err)
@@ -76,6 +81,7 @@ func Test_userStore_GetUser(t *testing.T) {
 }
 
 func Test_userStore_UpdateUser(t *testing.T) {
+	ctx := context.Background()
 	test_storage := New()
 	username := "test1"
 	test_user_init := &models.User{
@@ -93,17 +99,17 @@ func Test_userStore_UpdateUser(t *testing.T) {
 		UserEmail: "new_email@email.com",
 	}
 
-	test_storage.UserStore().AddUser(test_user_init)
+	test_storage.UserStore().AddUser(ctx, test_user_init)
 
-	retrieved_user, _ := test_storage.UserStore().GetUser(username)
+	retrieved_user, _ := test_storage.UserStore().GetUser(ctx, username)
 
 	if !reflect.DeepEqual(test_user_init, retrieved_user) {
 		t.Error("User did not match before updating")
 	}
 
-	test_storage.UserStore().UpdateUser(test_user_updated_password)
+	test_storage.UserStore().UpdateUser(ctx, test_user_updated_password)
 
-	updated_password_user, _ := test_storage.UserStore().GetUser(username)
+	updated_password_user, _ := test_storage.UserStore().GetUser(ctx, username)
 
 	if updated_password_user.UserEmail != test_user_init.UserEmail {
 		t.Error("UserEmail changed unexpectedly on password update")
@@ -113,9 +119,9 @@ func Test_userStore_UpdateUser(t *testing.T) {
 		t.Error("UserPassword did not change correctly on password update")
 	}
 
-	test_storage.UserStore().UpdateUser(test_user_updated_email)
+	test_storage.UserStore().UpdateUser(ctx, test_user_updated_email)
 
-	updated_email_user, _ := test_storage.UserStore().GetUser(username)
+	updated_email_user, _ := test_storage.UserStore().GetUser(ctx, username)
 
 	if updated_email_user.UserPassword != test_user_updated_password.UserPassword {
 		t.Error("UserPassword changed unexpectedly on email update")
@@ -125,3 +131,162 @@ func Test_userStore_UpdateUser(t *testing.T) {
 		t.Error("UserEmail did not change correctly on email update")
 	}
 }
+
+func Test_userStore_FollowUser(t *testing.T) {
+	ctx := context.Background()
+	test_storage := New()
+	defer test_storage.Close()
+	follower := &models.User{UserName: "follower"}
+	followed := &models.User{UserName: "followed"}
+	test_storage.UserStore().AddUser(ctx, follower)
+	test_storage.UserStore().AddUser(ctx, followed)
+	for i := 0; i < 2; i++ {
+		if err := test_storage.UserStore().FollowUser(ctx, follower, followed); err != nil {
+			t.Errorf("Error in following user: %+v\n", err)
+		}
+	}
+	retrieved_follower, _ := test_storage.UserStore().GetUser(ctx, "follower")
+	retrieved_followed, _ := test_storage.UserStore().GetUser(ctx, "followed")
+	if !reflect.DeepEqual(retrieved_follower.Follows, []string{"followed"}) || !reflect.DeepEqual(retrieved_followed.Followers, []string{"follower"}) {
+		t.Errorf("Following again not ignored: %+v %+v\n", retrieved_follower.Follows, retrieved_followed.Followers)
+	}
+}
+
+func Test_postStore_EditPost(t *testing.T) {
+	ctx := context.Background()
+	test_storage := New()
+	test_post, _ := test_storage.PostStore().CreatePost(ctx, &models.Post{
+		PostedBy: "test1",
+		Content:  "first version",
+	})
+	previous := &models.PostRevision{Content: test_post.Content}
+	edited_post := &models.Post{
+		PostID:   test_post.PostID,
+		PostedBy: test_post.PostedBy,
+		Content:  "second version",
+	}
+	_, err := test_storage.PostStore().EditPost(ctx, edited_post, previous)
+	if err != nil {
+		t.Errorf("Error in editing post: %+v\n", err)
+	}
+	retrieved_post, _ := test_storage.PostStore().GetPost(ctx, test_post.PostID)
+	if retrieved_post.Content != "second version" {
+		t.Errorf("Post content did not change on edit: %+v\n", retrieved_post)
+	}
+	revisions, _ := test_storage.PostStore().GetPostRevisions(ctx, test_post.PostID)
+	if len(revisions) != 1 || revisions[0].Content != "first version" {
+		t.Errorf("Unexpected revisions after edit: %+v\n", revisions)
+	}
+
+	_, err = test_storage.PostStore().EditPost(ctx, &models.Post{PostID: "missing"}, previous)
+	if err == nil {
+		t.Error("Able to edit a post that was never created")
+	}
+
+	test_storage.PostStore().DeletePost(ctx, retrieved_post)
+	revisions, _ = test_storage.PostStore().GetPostRevisions(ctx, test_post.PostID)
+	if len(revisions) != 0 {
+		t.Errorf("Revisions not removed along with the post: %+v\n", revisions)
+	}
+}
+
+func Test_postStore_reapExpiredPosts(t *testing.T) {
+	ctx := context.Background()
+	test_storage := New()
+	defer test_storage.Close()
+	now := time.Now()
+	ephemeral_post, _ := test_storage.PostStore().CreatePost(ctx, &models.Post{
+		PostedBy:  "test1",
+		Content:   "gone soon",
+		ExpiresAt: timestamppb.New(now.Add(time.Minute)),
+	})
+	lasting_post, _ := test_storage.PostStore().CreatePost(ctx, &models.Post{
+		PostedBy: "test1",
+		Content:  "here to stay",
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	expired_posts := test_storage.PostStore().WatchExpiredPosts(ctx)
+
+	posts := test_storage.(*memory).posts
+	posts.reapExpiredPosts(now)
+	if retrieved_post, _ := test_storage.PostStore().GetPost(ctx, ephemeral_post.PostID); retrieved_post == nil {
+		t.Error("Post removed before it expired")
+	}
+
+	go posts.reapExpiredPosts(now.Add(2 * time.Minute))
+	select {
+	case expired_post := <-expired_posts:
+		if expired_post.PostID != ephemeral_post.PostID {
+			t.Errorf("Unexpected post reported as expired: %+v\n", expired_post)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expired post was not reported to the watcher")
+	}
+	if retrieved_post, _ := test_storage.PostStore().GetPost(ctx, ephemeral_post.PostID); retrieved_post != nil {
+		t.Errorf("Expired post still present: %+v\n", retrieved_post)
+	}
+	if retrieved_post, _ := test_storage.PostStore().GetPost(ctx, lasting_post.PostID); retrieved_post == nil {
+		t.Error("Post without expiry was removed")
+	}
+}
+
+func Test_postStore_WatchPosts(t *testing.T) {
+	test_storage := New()
+	defer test_storage.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	post_events := test_storage.PostStore().WatchPosts(ctx)
+
+	go func() {
+		created_post, _ := test_storage.PostStore().CreatePost(ctx, &models.Post{PostedBy: "test1", Content: "first"})
+		test_storage.PostStore().EditPost(ctx, &models.Post{PostID: created_post.PostID, PostedBy: "test1", Content: "edited"}, &models.PostRevision{})
+		test_storage.PostStore().DeletePost(ctx, &models.Post{PostID: created_post.PostID, PostedBy: "test1"})
+	}()
+	for _, expected := range []struct {
+		content string
+		deleted bool
+	}{{"first", false}, {"edited", false}, {"edited", true}} {
+		select {
+		case post_event := <-post_events:
+			if post_event.Post.Content != expected.content || post_event.Deleted != expected.deleted {
+				t.Errorf("Unexpected post event: %+v\n", post_event)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Change not reported to the watcher: %+v\n", expected)
+		}
+	}
+	cancel()
+	if _, open := <-post_events; open {
+		t.Error("Watch not closed after its context was done")
+	}
+}
+
+func Test_bookmarkStore_RemovePost(t *testing.T) {
+	ctx := context.Background()
+	test_storage := New()
+	defer test_storage.Close()
+	first_post, _ := test_storage.PostStore().CreatePost(ctx, &models.Post{PostedBy: "test1", Content: "first"})
+	second_post, _ := test_storage.PostStore().CreatePost(ctx, &models.Post{PostedBy: "test1", Content: "second"})
+	now := time.Now()
+	test_storage.BookmarkStore().AddBookmark(ctx, "test2", first_post.PostID, now)
+	test_storage.BookmarkStore().AddBookmark(ctx, "test2", second_post.PostID, now.Add(time.Second))
+	test_storage.BookmarkStore().AddBookmark(ctx, "test3", first_post.PostID, now)
+
+	if err := test_storage.BookmarkStore().AddBookmark(ctx, "test2", "missing", now); err == nil {
+		t.Error("Able to bookmark a post that was never created")
+	}
+	bookmarks, _ := test_storage.BookmarkStore().GetBookmarks(ctx, "test2")
+	if !reflect.DeepEqual(bookmarks, []string{second_post.PostID, first_post.PostID}) {
+		t.Errorf("Bookmarks not ordered newest first: %+v\n", bookmarks)
+	}
+
+	test_storage.BookmarkStore().RemovePost(ctx, first_post.PostID)
+	bookmarks, _ = test_storage.BookmarkStore().GetBookmarks(ctx, "test2")
+	if !reflect.DeepEqual(bookmarks, []string{second_post.PostID}) {
+		t.Errorf("Removed post still bookmarked: %+v\n", bookmarks)
+	}
+	bookmarks, _ = test_storage.BookmarkStore().GetBookmarks(ctx, "test3")
+	if len(bookmarks) != 0 {
+		t.Errorf("Removed post still bookmarked by another user: %+v\n", bookmarks)
+	}
+}
@@ -0,0 +1,28 @@
+package memory
+
+import "context"
+
+func (u *userStore) PinPost(ctx context.Context, userName string, postId string) error {
+	if _, userExistsError := u.GetUser(ctx, userName); userExistsError != nil {
+		return userExistsError
+	}
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	u.pinnedPosts[userName] = postId
+	return nil
+}
+
+func (u *userStore) UnpinPost(ctx context.Context, userName string, postId string) error {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	if postId == "" || u.pinnedPosts[userName] == postId {
+		delete(u.pinnedPosts, userName)
+	}
+	return nil
+}
+
+func (u *userStore) GetPinnedPost(ctx context.Context, userName string) (string, error) {
+	u.mtx.RLock()
+	defer u.mtx.RUnlock()
+	return u.pinnedPosts[userName], nil
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/twitter/storage"
+)
+
+type pollStore struct {
+	mtx sync.Mutex
+	// post id -> username -> option voted for
+	votes map[string]map[string]int
+}
+
+func (p *pollStore) AddVote(ctx context.Context, postId string, userName string, option int) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if _, voted := p.votes[postId][userName]; voted {
+		return storage.ErrAlreadyVoted
+	}
+	if p.votes[postId] == nil {
+		p.votes[postId] = make(map[string]int)
+	}
+	p.votes[postId][userName] = option
+	return nil
+}
+
+func (p *pollStore) GetVote(ctx context.Context, postId string, userName string) (int, bool, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	option, voted := p.votes[postId][userName]
+	return option, voted, nil
+}
+
+func (p *pollStore) GetTallies(ctx context.Context, postId string, numOptions int) ([]int64, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	tallies := make([]int64, numOptions)
+	for _, option := range p.votes[postId] {
+		if option >= 0 && option < numOptions {
+			tallies[option] += 1
+		}
+	}
+	return tallies, nil
+}
+
+func (p *pollStore) RemovePoll(ctx context.Context, postId string) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	delete(p.votes, postId)
+	return nil
+}
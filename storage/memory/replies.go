@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/twitter/models"
+)
+
+func (ps *postStore) AddReply(ctx context.Context, reply *models.Post) error {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	if ps.replies[reply.ReplyTo] == nil {
+		ps.replies[reply.ReplyTo] = make(map[string]struct{})
+	}
+	ps.replies[reply.ReplyTo][reply.PostID] = struct{}{}
+	return nil
+}
+
+func (ps *postStore) RemoveReply(ctx context.Context, reply *models.Post) error {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	delete(ps.replies[reply.ReplyTo], reply.PostID)
+	if len(ps.replies[reply.ReplyTo]) == 0 {
+		delete(ps.replies, reply.ReplyTo)
+	}
+	return nil
+}
+
+func (ps *postStore) GetReplyCounts(ctx context.Context, postIds []string) (map[string]int64, error) {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+	counts := make(map[string]int64)
+	for _, postId := range postIds {
+		if replies := len(ps.replies[postId]); replies > 0 {
+			counts[postId] = int64(replies)
+		}
+	}
+	return counts, nil
+}
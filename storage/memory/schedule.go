@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/twitter/models"
+)
+
+type scheduleStore struct {
+	mtx            sync.Mutex
+	scheduledPosts map[string]*models.ScheduledPost
+	posts          *postStore
+}
+
+// elector always elects the only process sharing the memory
+type elector struct{}
+
+func (s *scheduleStore) AddScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.ScheduledPost, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	scheduledPost.ScheduleID = uuid.New().String()
+	s.scheduledPosts[scheduledPost.ScheduleID] = scheduledPost
+	return scheduledPost, nil
+}
+
+func (s *scheduleStore) GetDueScheduledPosts(ctx context.Context, now time.Time) ([]*models.ScheduledPost, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	duePosts := make([]*models.ScheduledPost, 0)
+	for _, scheduledPost := range s.scheduledPosts {
+		if !scheduledPost.PublishAt.AsTime().After(now) {
+			duePosts = append(duePosts, scheduledPost)
+		}
+	}
+	return duePosts, nil
+}
+
+func (s *scheduleStore) PublishScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.Post, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, exists := s.scheduledPosts[scheduledPost.ScheduleID]; !exists {
+		return nil, nil
+	}
+	publishedPost, err := s.posts.CreatePost(ctx, scheduledPost.Post)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.scheduledPosts, scheduledPost.ScheduleID)
+	return publishedPost, nil
+}
+
+func (e *elector) Campaign(ctx context.Context, _ string) (<-chan struct{}, error) {
+	lost := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(lost)
+	}()
+	return lost, nil
+}
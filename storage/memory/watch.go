@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+)
+
+// watchers hands every change to the channels watching it until their context is done
+type watchers[T any] struct {
+	mtx      sync.Mutex
+	channels map[chan T]context.Context
+}
+
+func (w *watchers[T]) watch(ctx context.Context) <-chan T {
+	changes := make(chan T)
+	w.mtx.Lock()
+	w.channels[changes] = ctx
+	w.mtx.Unlock()
+	go func() {
+		<-ctx.Done()
+		w.mtx.Lock()
+		delete(w.channels, changes)
+		close(changes)
+		w.mtx.Unlock()
+	}()
+	return changes
+}
+
+// notify expects the caller not to hold the locks of the store, the watchers may be slow to receive
+func (w *watchers[T]) notify(change T) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	for changes, ctx := range w.channels {
+		select {
+		case changes <- change:
+		case <-ctx.Done():
+		}
+	}
+}
+
+func (u *userStore) AddUser(ctx context.Context, newUser *models.User) (*models.User, error) {
+	addedUser, err := u.addUser(ctx, newUser)
+	if err != nil {
+		return nil, err
+	}
+	u.changes.notify(addedUser)
+	return addedUser, nil
+}
+
+func (u *userStore) UpdateUser(ctx context.Context, updatedUser *models.User) (*models.User, error) {
+	updatedUser, err := u.updateUser(ctx, updatedUser)
+	if err != nil {
+		return nil, err
+	}
+	u.changes.notify(updatedUser)
+	return updatedUser, nil
+}
+
+func (u *userStore) WatchUsers(ctx context.Context) <-chan *models.User {
+	return u.changes.watch(ctx)
+}
+
+func (p *postStore) CreatePost(ctx context.Context, newPost *models.Post) (*models.Post, error) {
+	createdPost, err := p.createPost(ctx, newPost)
+	if err != nil {
+		return nil, err
+	}
+	p.changes.notify(storage.PostEvent{Post: createdPost})
+	return createdPost, nil
+}
+
+func (p *postStore) EditPost(ctx context.Context, editedPost *models.Post, previous *models.PostRevision) (*models.Post, error) {
+	editedPost, err := p.editPost(ctx, editedPost, previous)
+	if err != nil {
+		return nil, err
+	}
+	p.changes.notify(storage.PostEvent{Post: editedPost})
+	return editedPost, nil
+}
+
+func (p *postStore) DeletePost(ctx context.Context, postToDelete *models.Post) error {
+	if storedPost, err := p.GetPost(ctx, postToDelete.PostID); err == nil {
+		postToDelete = storedPost
+	}
+	if err := p.deletePost(ctx, postToDelete); err != nil {
+		return err
+	}
+	p.changes.notify(storage.PostEvent{Post: postToDelete, Deleted: true})
+	return nil
+}
+
+func (p *postStore) WatchPosts(ctx context.Context) <-chan storage.PostEvent {
+	return p.changes.watch(ctx)
+}
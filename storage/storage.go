@@ -1,26 +1,156 @@
 package storage
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"github.com/twitter/models"
 )
 
+var (
+	ErrAlreadyVoted = errors.New("User already voted in this poll")
+	ErrNoLeader     = errors.New("No storage endpoint has an elected leader")
+	ErrPostNotFound = errors.New("Post not found")
+)
+
+// PostEvent is a change of a post seen by WatchPosts
+type PostEvent struct {
+	Post *models.Post
+	// Deleted is set when the post was removed, Post is then the post as it was before
+	Deleted bool
+}
+
 type UserStore interface {
-	AddUser(*models.User) (*models.User, error)
-	GetUser(string) (*models.User, error)
-	UpdateUser(*models.User) (*models.User, error)
-	FollowUser(*models.User, *models.User) error
-	UnFollowUser(curUser *models.User, userToUnFollow *models.User) error
+	AddUser(ctx context.Context, newUser *models.User) (*models.User, error)
+	GetUser(ctx context.Context, userName string) (*models.User, error)
+	GetAllUsers(ctx context.Context) ([]*models.User, error)
+	CountUsers(ctx context.Context) (int64, error)
+	UpdateUser(ctx context.Context, updatedUser *models.User) (*models.User, error)
+	FollowUser(ctx context.Context, curUser *models.User, userToFollow *models.User) error
+	UnFollowUser(ctx context.Context, curUser *models.User, userToUnFollow *models.User) error
+	// PinPost makes the post the user's pinned post, replacing any previously pinned post
+	PinPost(ctx context.Context, userName string, postId string) error
+	// UnpinPost clears the user's pinned post if it is the given post, or whatever it is if postId is empty
+	UnpinPost(ctx context.Context, userName string, postId string) error
+	// GetPinnedPost returns the id of the user's pinned post, empty if nothing is pinned
+	GetPinnedPost(ctx context.Context, userName string) (string, error)
+	BlockUser(ctx context.Context, userName string, userToBlock string) error
+	UnBlockUser(ctx context.Context, userName string, userToUnBlock string) error
+	// GetBlockedUsers returns the usernames the user has blocked
+	GetBlockedUsers(ctx context.Context, userName string) ([]string, error)
+	// WatchUsers returns a channel receiving every user added or updated after the call, through any server,
+	// the channel is closed when ctx is done
+	WatchUsers(ctx context.Context) <-chan *models.User
 }
 
 type PostStore interface {
-	CreatePost(*models.Post) (*models.Post, error)
-	DeletePost(*models.Post) error
-	GetPosts(*models.User) ([]*models.Post, error)
-	GetPost(string) (*models.Post, error)
+	CreatePost(ctx context.Context, newPost *models.Post) (*models.Post, error)
+	DeletePost(ctx context.Context, postToDelete *models.Post) error
+	GetPosts(ctx context.Context, postedBy *models.User) ([]*models.Post, error)
+	// GetPost fails with ErrPostNotFound if there's no post with the id
+	GetPost(ctx context.Context, postId string) (*models.Post, error)
+	GetAllPosts(ctx context.Context) ([]*models.Post, error)
+	CountPosts(ctx context.Context) (int64, error)
+	// EditPost replaces the stored post and keeps the content it replaced as a revision
+	EditPost(ctx context.Context, editedPost *models.Post, previous *models.PostRevision) (*models.Post, error)
+	// GetPostRevisions returns the previous versions of the post, oldest first
+	GetPostRevisions(ctx context.Context, postId string) ([]*models.PostRevision, error)
+	// WatchExpiredPosts returns a channel receiving every post removed once its ExpiresAt passed,
+	// the channel is closed when ctx is done
+	WatchExpiredPosts(ctx context.Context) <-chan *models.Post
+	// WatchPosts returns a channel receiving every post created, edited or removed after the call, through any
+	// server, the channel is closed when ctx is done
+	WatchPosts(ctx context.Context) <-chan PostEvent
+	// AddReply indexes the post as a reply to the post in its ReplyTo
+	AddReply(ctx context.Context, reply *models.Post) error
+	RemoveReply(ctx context.Context, reply *models.Post) error
+	// GetReplyCounts returns the number of replies of each of the posts, posts without replies are left out
+	GetReplyCounts(ctx context.Context, postIds []string) (map[string]int64, error)
+}
+
+type HashtagStore interface {
+	// AddPost indexes the post under the tag and counts it towards the tag's trend
+	AddPost(ctx context.Context, tag string, post *models.Post) error
+	// RemovePost removes the post from the tag's index and trend counts
+	RemovePost(ctx context.Context, tag string, post *models.Post) error
+	// GetPostIDs returns the ids of all the posts indexed under the tag
+	GetPostIDs(ctx context.Context, tag string) ([]string, error)
+	// GetTrendCounts returns the number of posts per tag posted after the given time
+	GetTrendCounts(ctx context.Context, since time.Time) (map[string]int64, error)
+}
+
+type ScheduleStore interface {
+	AddScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.ScheduledPost, error)
+	// GetDueScheduledPosts returns the scheduled posts due to be published at the given time
+	GetDueScheduledPosts(ctx context.Context, now time.Time) ([]*models.ScheduledPost, error)
+	// PublishScheduledPost atomically removes the scheduled post and creates its post.
+	// It returns a nil post if the scheduled post was already published.
+	PublishScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.Post, error)
+}
+
+type BookmarkStore interface {
+	// AddBookmark bookmarks the post for the user, failing if the post doesn't exist
+	AddBookmark(ctx context.Context, userName string, postId string, bookmarkedAt time.Time) error
+	RemoveBookmark(ctx context.Context, userName string, postId string) error
+	// GetBookmarks returns the ids of the posts bookmarked by the user, most recently bookmarked first
+	GetBookmarks(ctx context.Context, userName string) ([]string, error)
+	// RemovePost removes the post from the bookmarks of every user
+	RemovePost(ctx context.Context, postId string) error
+}
+
+type PollStore interface {
+	// AddVote records the user's vote for the option, failing with ErrAlreadyVoted if the user voted before
+	AddVote(ctx context.Context, postId string, userName string, option int) error
+	// GetVote returns the option the user voted for and whether the user voted at all
+	GetVote(ctx context.Context, postId string, userName string) (int, bool, error)
+	// GetTallies returns the number of votes of each of the options
+	GetTallies(ctx context.Context, postId string, numOptions int) ([]int64, error)
+	// RemovePoll removes every vote of the post's poll
+	RemovePoll(ctx context.Context, postId string) error
+}
+
+type ListStore interface {
+	// CreateList stores a new list, assigning its id
+	CreateList(ctx context.Context, newList *models.UserList) (*models.UserList, error)
+	// GetList returns the list along with its members
+	GetList(ctx context.Context, listId string) (*models.UserList, error)
+	// GetLists returns every list owned by the user, members included
+	GetLists(ctx context.Context, owner string) ([]*models.UserList, error)
+	// UpdateList changes the name and visibility of the list
+	UpdateList(ctx context.Context, updatedList *models.UserList) (*models.UserList, error)
+	DeleteList(ctx context.Context, listId string) error
+	AddMember(ctx context.Context, listId string, userName string) error
+	RemoveMember(ctx context.Context, listId string, userName string) error
+}
+
+type ImpressionStore interface {
+	// AddImpressions adds the counts to the number of times each of the posts was seen, skipping the posts that don't
+	// exist. It returns the ids of the posts it is done with, which it may have added or skipped even when it fails.
+	AddImpressions(ctx context.Context, counts map[string]int64) ([]string, error)
+	// GetImpressions returns the number of times each of the posts was seen, posts never seen are left out
+	GetImpressions(ctx context.Context, postIds []string) (map[string]int64, error)
+	RemovePost(ctx context.Context, postId string) error
+}
+
+type Elector interface {
+	// Campaign blocks until this process is elected leader of the named election or ctx is done.
+	// The returned channel is closed once the leadership is lost, and the leadership is given up
+	// when ctx is done.
+	Campaign(ctx context.Context, election string) (<-chan struct{}, error)
 }
 
 type Storage interface {
 	UserStore() UserStore
 	PostStore() PostStore
+	HashtagStore() HashtagStore
+	ScheduleStore() ScheduleStore
+	BookmarkStore() BookmarkStore
+	PollStore() PollStore
+	ListStore() ListStore
+	ImpressionStore() ImpressionStore
+	Elector() Elector
+	// Ping checks whether the storage is able to serve requests
+	Ping(ctx context.Context) error
 	Close()
 }
@@ -0,0 +1,404 @@
+package traced
+
+import (
+	"context"
+	"time"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+)
+
+type userStore struct {
+	spans
+	store storage.UserStore
+}
+
+type postStore struct {
+	spans
+	store storage.PostStore
+}
+
+type hashtagStore struct {
+	spans
+	store storage.HashtagStore
+}
+
+type scheduleStore struct {
+	spans
+	store storage.ScheduleStore
+}
+
+type bookmarkStore struct {
+	spans
+	store storage.BookmarkStore
+}
+
+type pollStore struct {
+	spans
+	store storage.PollStore
+}
+
+type listStore struct {
+	spans
+	store storage.ListStore
+}
+
+type impressionStore struct {
+	spans
+	store storage.ImpressionStore
+}
+
+func (u *userStore) AddUser(ctx context.Context, newUser *models.User) (*models.User, error) {
+	ctx, span := u.start(ctx, "AddUser")
+	result, err := u.store.AddUser(ctx, newUser)
+	end(span, err)
+	return result, err
+}
+
+func (u *userStore) GetUser(ctx context.Context, userName string) (*models.User, error) {
+	ctx, span := u.start(ctx, "GetUser")
+	result, err := u.store.GetUser(ctx, userName)
+	end(span, err)
+	return result, err
+}
+
+func (u *userStore) GetAllUsers(ctx context.Context) ([]*models.User, error) {
+	ctx, span := u.start(ctx, "GetAllUsers")
+	result, err := u.store.GetAllUsers(ctx)
+	end(span, err)
+	return result, err
+}
+
+func (u *userStore) CountUsers(ctx context.Context) (int64, error) {
+	ctx, span := u.start(ctx, "CountUsers")
+	result, err := u.store.CountUsers(ctx)
+	end(span, err)
+	return result, err
+}
+
+func (u *userStore) UpdateUser(ctx context.Context, updatedUser *models.User) (*models.User, error) {
+	ctx, span := u.start(ctx, "UpdateUser")
+	result, err := u.store.UpdateUser(ctx, updatedUser)
+	end(span, err)
+	return result, err
+}
+
+func (u *userStore) FollowUser(ctx context.Context, curUser *models.User, userToFollow *models.User) error {
+	ctx, span := u.start(ctx, "FollowUser")
+	err := u.store.FollowUser(ctx, curUser, userToFollow)
+	end(span, err)
+	return err
+}
+
+func (u *userStore) UnFollowUser(ctx context.Context, curUser *models.User, userToUnFollow *models.User) error {
+	ctx, span := u.start(ctx, "UnFollowUser")
+	err := u.store.UnFollowUser(ctx, curUser, userToUnFollow)
+	end(span, err)
+	return err
+}
+
+func (u *userStore) PinPost(ctx context.Context, userName string, postId string) error {
+	ctx, span := u.start(ctx, "PinPost")
+	err := u.store.PinPost(ctx, userName, postId)
+	end(span, err)
+	return err
+}
+
+func (u *userStore) UnpinPost(ctx context.Context, userName string, postId string) error {
+	ctx, span := u.start(ctx, "UnpinPost")
+	err := u.store.UnpinPost(ctx, userName, postId)
+	end(span, err)
+	return err
+}
+
+func (u *userStore) GetPinnedPost(ctx context.Context, userName string) (string, error) {
+	ctx, span := u.start(ctx, "GetPinnedPost")
+	result, err := u.store.GetPinnedPost(ctx, userName)
+	end(span, err)
+	return result, err
+}
+
+func (u *userStore) BlockUser(ctx context.Context, userName string, userToBlock string) error {
+	ctx, span := u.start(ctx, "BlockUser")
+	err := u.store.BlockUser(ctx, userName, userToBlock)
+	end(span, err)
+	return err
+}
+
+func (u *userStore) UnBlockUser(ctx context.Context, userName string, userToUnBlock string) error {
+	ctx, span := u.start(ctx, "UnBlockUser")
+	err := u.store.UnBlockUser(ctx, userName, userToUnBlock)
+	end(span, err)
+	return err
+}
+
+func (u *userStore) GetBlockedUsers(ctx context.Context, userName string) ([]string, error) {
+	ctx, span := u.start(ctx, "GetBlockedUsers")
+	result, err := u.store.GetBlockedUsers(ctx, userName)
+	end(span, err)
+	return result, err
+}
+
+func (u *userStore) WatchUsers(ctx context.Context) <-chan *models.User {
+	return u.store.WatchUsers(ctx)
+}
+
+func (p *postStore) CreatePost(ctx context.Context, newPost *models.Post) (*models.Post, error) {
+	ctx, span := p.start(ctx, "CreatePost")
+	result, err := p.store.CreatePost(ctx, newPost)
+	end(span, err)
+	return result, err
+}
+
+func (p *postStore) DeletePost(ctx context.Context, postToDelete *models.Post) error {
+	ctx, span := p.start(ctx, "DeletePost")
+	err := p.store.DeletePost(ctx, postToDelete)
+	end(span, err)
+	return err
+}
+
+func (p *postStore) GetPosts(ctx context.Context, postedBy *models.User) ([]*models.Post, error) {
+	ctx, span := p.start(ctx, "GetPosts")
+	result, err := p.store.GetPosts(ctx, postedBy)
+	end(span, err)
+	return result, err
+}
+
+func (p *postStore) GetPost(ctx context.Context, postId string) (*models.Post, error) {
+	ctx, span := p.start(ctx, "GetPost")
+	result, err := p.store.GetPost(ctx, postId)
+	end(span, err)
+	return result, err
+}
+
+func (p *postStore) GetAllPosts(ctx context.Context) ([]*models.Post, error) {
+	ctx, span := p.start(ctx, "GetAllPosts")
+	result, err := p.store.GetAllPosts(ctx)
+	end(span, err)
+	return result, err
+}
+
+func (p *postStore) CountPosts(ctx context.Context) (int64, error) {
+	ctx, span := p.start(ctx, "CountPosts")
+	result, err := p.store.CountPosts(ctx)
+	end(span, err)
+	return result, err
+}
+
+func (p *postStore) EditPost(ctx context.Context, editedPost *models.Post, previous *models.PostRevision) (*models.Post, error) {
+	ctx, span := p.start(ctx, "EditPost")
+	result, err := p.store.EditPost(ctx, editedPost, previous)
+	end(span, err)
+	return result, err
+}
+
+func (p *postStore) GetPostRevisions(ctx context.Context, postId string) ([]*models.PostRevision, error) {
+	ctx, span := p.start(ctx, "GetPostRevisions")
+	result, err := p.store.GetPostRevisions(ctx, postId)
+	end(span, err)
+	return result, err
+}
+
+func (p *postStore) WatchExpiredPosts(ctx context.Context) <-chan *models.Post {
+	return p.store.WatchExpiredPosts(ctx)
+}
+
+func (p *postStore) WatchPosts(ctx context.Context) <-chan storage.PostEvent {
+	return p.store.WatchPosts(ctx)
+}
+
+func (p *postStore) AddReply(ctx context.Context, reply *models.Post) error {
+	ctx, span := p.start(ctx, "AddReply")
+	err := p.store.AddReply(ctx, reply)
+	end(span, err)
+	return err
+}
+
+func (p *postStore) RemoveReply(ctx context.Context, reply *models.Post) error {
+	ctx, span := p.start(ctx, "RemoveReply")
+	err := p.store.RemoveReply(ctx, reply)
+	end(span, err)
+	return err
+}
+
+func (p *postStore) GetReplyCounts(ctx context.Context, postIds []string) (map[string]int64, error) {
+	ctx, span := p.start(ctx, "GetReplyCounts")
+	result, err := p.store.GetReplyCounts(ctx, postIds)
+	end(span, err)
+	return result, err
+}
+
+func (h *hashtagStore) AddPost(ctx context.Context, tag string, post *models.Post) error {
+	ctx, span := h.start(ctx, "AddPost")
+	err := h.store.AddPost(ctx, tag, post)
+	end(span, err)
+	return err
+}
+
+func (h *hashtagStore) RemovePost(ctx context.Context, tag string, post *models.Post) error {
+	ctx, span := h.start(ctx, "RemovePost")
+	err := h.store.RemovePost(ctx, tag, post)
+	end(span, err)
+	return err
+}
+
+func (h *hashtagStore) GetPostIDs(ctx context.Context, tag string) ([]string, error) {
+	ctx, span := h.start(ctx, "GetPostIDs")
+	result, err := h.store.GetPostIDs(ctx, tag)
+	end(span, err)
+	return result, err
+}
+
+func (h *hashtagStore) GetTrendCounts(ctx context.Context, since time.Time) (map[string]int64, error) {
+	ctx, span := h.start(ctx, "GetTrendCounts")
+	result, err := h.store.GetTrendCounts(ctx, since)
+	end(span, err)
+	return result, err
+}
+
+func (s *scheduleStore) AddScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.ScheduledPost, error) {
+	ctx, span := s.start(ctx, "AddScheduledPost")
+	result, err := s.store.AddScheduledPost(ctx, scheduledPost)
+	end(span, err)
+	return result, err
+}
+
+func (s *scheduleStore) GetDueScheduledPosts(ctx context.Context, now time.Time) ([]*models.ScheduledPost, error) {
+	ctx, span := s.start(ctx, "GetDueScheduledPosts")
+	result, err := s.store.GetDueScheduledPosts(ctx, now)
+	end(span, err)
+	return result, err
+}
+
+func (s *scheduleStore) PublishScheduledPost(ctx context.Context, scheduledPost *models.ScheduledPost) (*models.Post, error) {
+	ctx, span := s.start(ctx, "PublishScheduledPost")
+	result, err := s.store.PublishScheduledPost(ctx, scheduledPost)
+	end(span, err)
+	return result, err
+}
+
+func (b *bookmarkStore) AddBookmark(ctx context.Context, userName string, postId string, bookmarkedAt time.Time) error {
+	ctx, span := b.start(ctx, "AddBookmark")
+	err := b.store.AddBookmark(ctx, userName, postId, bookmarkedAt)
+	end(span, err)
+	return err
+}
+
+func (b *bookmarkStore) RemoveBookmark(ctx context.Context, userName string, postId string) error {
+	ctx, span := b.start(ctx, "RemoveBookmark")
+	err := b.store.RemoveBookmark(ctx, userName, postId)
+	end(span, err)
+	return err
+}
+
+func (b *bookmarkStore) GetBookmarks(ctx context.Context, userName string) ([]string, error) {
+	ctx, span := b.start(ctx, "GetBookmarks")
+	result, err := b.store.GetBookmarks(ctx, userName)
+	end(span, err)
+	return result, err
+}
+
+func (b *bookmarkStore) RemovePost(ctx context.Context, postId string) error {
+	ctx, span := b.start(ctx, "RemovePost")
+	err := b.store.RemovePost(ctx, postId)
+	end(span, err)
+	return err
+}
+
+func (p *pollStore) AddVote(ctx context.Context, postId string, userName string, option int) error {
+	ctx, span := p.start(ctx, "AddVote")
+	err := p.store.AddVote(ctx, postId, userName, option)
+	end(span, err)
+	return err
+}
+
+func (p *pollStore) GetVote(ctx context.Context, postId string, userName string) (int, bool, error) {
+	ctx, span := p.start(ctx, "GetVote")
+	option, voted, err := p.store.GetVote(ctx, postId, userName)
+	end(span, err)
+	return option, voted, err
+}
+
+func (p *pollStore) GetTallies(ctx context.Context, postId string, numOptions int) ([]int64, error) {
+	ctx, span := p.start(ctx, "GetTallies")
+	result, err := p.store.GetTallies(ctx, postId, numOptions)
+	end(span, err)
+	return result, err
+}
+
+func (p *pollStore) RemovePoll(ctx context.Context, postId string) error {
+	ctx, span := p.start(ctx, "RemovePoll")
+	err := p.store.RemovePoll(ctx, postId)
+	end(span, err)
+	return err
+}
+
+func (l *listStore) CreateList(ctx context.Context, newList *models.UserList) (*models.UserList, error) {
+	ctx, span := l.start(ctx, "CreateList")
+	result, err := l.store.CreateList(ctx, newList)
+	end(span, err)
+	return result, err
+}
+
+func (l *listStore) GetList(ctx context.Context, listId string) (*models.UserList, error) {
+	ctx, span := l.start(ctx, "GetList")
+	result, err := l.store.GetList(ctx, listId)
+	end(span, err)
+	return result, err
+}
+
+func (l *listStore) GetLists(ctx context.Context, owner string) ([]*models.UserList, error) {
+	ctx, span := l.start(ctx, "GetLists")
+	result, err := l.store.GetLists(ctx, owner)
+	end(span, err)
+	return result, err
+}
+
+func (l *listStore) UpdateList(ctx context.Context, updatedList *models.UserList) (*models.UserList, error) {
+	ctx, span := l.start(ctx, "UpdateList")
+	result, err := l.store.UpdateList(ctx, updatedList)
+	end(span, err)
+	return result, err
+}
+
+func (l *listStore) DeleteList(ctx context.Context, listId string) error {
+	ctx, span := l.start(ctx, "DeleteList")
+	err := l.store.DeleteList(ctx, listId)
+	end(span, err)
+	return err
+}
+
+func (l *listStore) AddMember(ctx context.Context, listId string, userName string) error {
+	ctx, span := l.start(ctx, "AddMember")
+	err := l.store.AddMember(ctx, listId, userName)
+	end(span, err)
+	return err
+}
+
+func (l *listStore) RemoveMember(ctx context.Context, listId string, userName string) error {
+	ctx, span := l.start(ctx, "RemoveMember")
+	err := l.store.RemoveMember(ctx, listId, userName)
+	end(span, err)
+	return err
+}
+
+func (i *impressionStore) AddImpressions(ctx context.Context, counts map[string]int64) ([]string, error) {
+	ctx, span := i.start(ctx, "AddImpressions")
+	result, err := i.store.AddImpressions(ctx, counts)
+	end(span, err)
+	return result, err
+}
+
+func (i *impressionStore) GetImpressions(ctx context.Context, postIds []string) (map[string]int64, error) {
+	ctx, span := i.start(ctx, "GetImpressions")
+	result, err := i.store.GetImpressions(ctx, postIds)
+	end(span, err)
+	return result, err
+}
+
+func (i *impressionStore) RemovePost(ctx context.Context, postId string) error {
+	ctx, span := i.start(ctx, "RemovePost")
+	err := i.store.RemovePost(ctx, postId)
+	end(span, err)
+	return err
+}
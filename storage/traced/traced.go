@@ -0,0 +1,105 @@
+// Package traced decorates a storage backend with a span around every storage operation
+package traced
+
+import (
+	"context"
+
+	"github.com/twitter/storage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/twitter/storage/traced"
+
+// spans starts the spans of the operations of one of the stores
+type spans struct {
+	store  string
+	tracer trace.Tracer
+}
+
+// start starts the span of the operation as a child of the span in ctx, named after the store and operation
+func (s spans) start(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "storage "+s.store+"."+operation, trace.WithSpanKind(trace.SpanKindClient))
+}
+
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+type traced struct {
+	db          storage.Storage
+	users       *userStore
+	posts       *postStore
+	hashtags    *hashtagStore
+	schedules   *scheduleStore
+	bookmarks   *bookmarkStore
+	polls       *pollStore
+	lists       *listStore
+	impressions *impressionStore
+}
+
+func (t *traced) UserStore() storage.UserStore {
+	return t.users
+}
+
+func (t *traced) PostStore() storage.PostStore {
+	return t.posts
+}
+
+func (t *traced) HashtagStore() storage.HashtagStore {
+	return t.hashtags
+}
+
+func (t *traced) ScheduleStore() storage.ScheduleStore {
+	return t.schedules
+}
+
+func (t *traced) BookmarkStore() storage.BookmarkStore {
+	return t.bookmarks
+}
+
+func (t *traced) PollStore() storage.PollStore {
+	return t.polls
+}
+
+func (t *traced) ListStore() storage.ListStore {
+	return t.lists
+}
+
+func (t *traced) ImpressionStore() storage.ImpressionStore {
+	return t.impressions
+}
+
+// Elector isn't traced, campaigning blocks for as long as the election takes
+func (t *traced) Elector() storage.Elector {
+	return t.db.Elector()
+}
+
+// Ping isn't traced, it's polled by the health checks rather than serving requests
+func (t *traced) Ping(ctx context.Context) error {
+	return t.db.Ping(ctx)
+}
+
+func (t *traced) Close() {
+	t.db.Close()
+}
+
+// New wraps the storage so that every operation is recorded as a span of the provider's tracer
+func New(db storage.Storage, provider trace.TracerProvider) storage.Storage {
+	tracer := provider.Tracer(tracerName)
+	return &traced{
+		db:          db,
+		users:       &userStore{spans{"users", tracer}, db.UserStore()},
+		posts:       &postStore{spans{"posts", tracer}, db.PostStore()},
+		hashtags:    &hashtagStore{spans{"hashtags", tracer}, db.HashtagStore()},
+		schedules:   &scheduleStore{spans{"schedules", tracer}, db.ScheduleStore()},
+		bookmarks:   &bookmarkStore{spans{"bookmarks", tracer}, db.BookmarkStore()},
+		polls:       &pollStore{spans{"polls", tracer}, db.PollStore()},
+		lists:       &listStore{spans{"lists", tracer}, db.ListStore()},
+		impressions: &impressionStore{spans{"impressions", tracer}, db.ImpressionStore()},
+	}
+}
@@ -0,0 +1,50 @@
+package traced
+
+import (
+	"context"
+	"testing"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage/memory"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraced(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	test_storage := New(memory.New(), provider)
+	defer test_storage.Close()
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "handler")
+	test_storage.UserStore().AddUser(ctx, &models.User{UserName: "test1"})
+	if _, err := test_storage.UserStore().AddUser(ctx, &models.User{UserName: "test1"}); err == nil {
+		t.Error("Able to add a duplicate user through the traced storage")
+	}
+	created_post, err := test_storage.PostStore().CreatePost(ctx, &models.Post{PostedBy: "test1", Content: "post"})
+	if err != nil || created_post.PostID == "" {
+		t.Errorf("Post not created through the traced storage: %+v %+v\n", created_post, err)
+	}
+	parent.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 4 {
+		t.Fatalf("Unexpected number of spans: %+v\n", len(ended))
+	}
+	expected_names := []string{"storage users.AddUser", "storage users.AddUser", "storage posts.CreatePost"}
+	for i, name := range expected_names {
+		if ended[i].Name() != name {
+			t.Errorf("Unexpected span name: %+v\n", ended[i].Name())
+		}
+		if ended[i].Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Span %+v isn't a child of the handler span\n", ended[i].Name())
+		}
+	}
+	if ended[0].Status().Code == codes.Error || ended[2].Status().Code == codes.Error {
+		t.Error("Successful operation recorded as an error")
+	}
+	if ended[1].Status().Code != codes.Error {
+		t.Errorf("Failed operation not recorded as an error: %+v\n", ended[1].Status())
+	}
+}
@@ -0,0 +1,198 @@
+// Package tlsconfig builds the TLS configurations of the servers and clients from certificate files, the files are
+// reloaded when they change on disk so that certificates can be rotated without a restart
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoCertificate = errors.New("A certificate and its key are required")
+	ErrNoPeerCert    = errors.New("The peer presented no certificate")
+	ErrInvalidCA     = errors.New("No certificate found in the CA file")
+)
+
+// Config names the files of the TLS settings of one side of a connection
+type Config struct {
+	// CertFile and KeyFile are the certificate presented to the peer, required by servers and optional for clients
+	CertFile string
+	KeyFile  string
+	// CAFile verifies the peer's certificates. Servers require a client certificate signed by it when it's set,
+	// clients verify the server with the system roots when it isn't.
+	CAFile string
+	// ServerName overrides the name the server's certificate is verified against
+	ServerName string
+}
+
+// files holds the certificates loaded from the files of the config, reloading them once the files change
+type files struct {
+	config   Config
+	mtx      sync.Mutex
+	modTimes map[string]time.Time
+	cert     *tls.Certificate
+	pool     *x509.CertPool
+}
+
+func newFiles(config Config) (*files, error) {
+	f := &files{config: config}
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *files) names() []string {
+	var names []string
+	for _, name := range []string{f.config.CertFile, f.config.KeyFile, f.config.CAFile} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// changed reports whether any of the files was modified since they were last loaded, the times are updated so that
+// a failed reload isn't attempted again until the files change again
+func (f *files) changed() bool {
+	changed := false
+	for _, name := range f.names() {
+		info, err := os.Stat(name)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(f.modTimes[name]) {
+			f.modTimes[name] = info.ModTime()
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (f *files) reload() error {
+	modTimes := map[string]time.Time{}
+	for _, name := range f.names() {
+		info, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+		modTimes[name] = info.ModTime()
+	}
+	var cert *tls.Certificate
+	if f.config.CertFile != "" || f.config.KeyFile != "" {
+		loaded, err := tls.LoadX509KeyPair(f.config.CertFile, f.config.KeyFile)
+		if err != nil {
+			return err
+		}
+		cert = &loaded
+	}
+	var pool *x509.CertPool
+	if f.config.CAFile != "" {
+		pem, err := os.ReadFile(f.config.CAFile)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return ErrInvalidCA
+		}
+	}
+	f.modTimes, f.cert, f.pool = modTimes, cert, pool
+	return nil
+}
+
+// current returns the certificates, reloaded first if the files changed. The previous certificates are kept when
+// the new files can't be loaded, such as while only some of them were replaced.
+func (f *files) current() (*tls.Certificate, *x509.CertPool) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.changed() {
+		if err := f.reload(); err != nil {
+			slog.Warn("Unable to reload the certificates, keeping the previous ones", "files", f.names(), "err", err)
+		} else {
+			slog.Info("Reloaded the certificates", "files", f.names())
+		}
+	}
+	return f.cert, f.pool
+}
+
+// verify verifies the certificate chain of the peer against the CA, the standard verification can't be used since
+// the CA may change after the configuration is created
+func (f *files) verify(state tls.ConnectionState, usage x509.ExtKeyUsage, dnsName string) error {
+	if len(state.PeerCertificates) == 0 {
+		return ErrNoPeerCert
+	}
+	_, pool := f.current()
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		DNSName:       dnsName,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+	return err
+}
+
+// Server creates the configuration of a server presenting the certificate of the config, clients have to present a
+// certificate signed by the CA when the config has one
+func Server(config Config) (*tls.Config, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, ErrNoCertificate
+	}
+	f, err := newFiles(config)
+	if err != nil {
+		return nil, err
+	}
+	serverConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _ := f.current()
+			return cert, nil
+		},
+	}
+	if config.CAFile != "" {
+		serverConfig.ClientAuth = tls.RequireAnyClientCert
+		serverConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			return f.verify(state, x509.ExtKeyUsageClientAuth, "")
+		}
+	}
+	return serverConfig, nil
+}
+
+// Client creates the configuration of a client verifying the server with the CA of the config, or the system roots
+// without one, and presenting the certificate of the config when it has one
+func Client(config Config) (*tls.Config, error) {
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return nil, ErrNoCertificate
+	}
+	f, err := newFiles(config)
+	if err != nil {
+		return nil, err
+	}
+	clientConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.ServerName,
+	}
+	if config.CertFile != "" {
+		clientConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := f.current()
+			return cert, nil
+		}
+	}
+	if config.CAFile != "" {
+		// the chain is verified by VerifyConnection instead, against the CA as currently on disk
+		clientConfig.InsecureSkipVerify = true
+		clientConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			return f.verify(state, x509.ExtKeyUsageServerAuth, state.ServerName)
+		}
+	}
+	return clientConfig, nil
+}
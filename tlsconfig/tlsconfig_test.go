@@ -0,0 +1,172 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// stamp is the modification time of the last file written, the files of a test are rewritten faster than the
+// resolution of some file systems' modification times
+var stamp = time.Now()
+
+func writeFile(t *testing.T, name string, content []byte) {
+	if err := os.WriteFile(name, content, 0600); err != nil {
+		t.Fatalf("Error in writing %+v: %+v\n", name, err)
+	}
+	stamp = stamp.Add(time.Second)
+	os.Chtimes(name, stamp, stamp)
+}
+
+func writePEM(t *testing.T, name string, blockType string, der []byte) {
+	writeFile(t, name, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}))
+}
+
+// newAuthority creates a CA and writes its certificate to the file
+func newAuthority(t *testing.T, name string) *authority {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Error in creating the CA: %+v\n", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	writePEM(t, name, "CERTIFICATE", der)
+	return &authority{cert, key}
+}
+
+// issue writes a certificate signed by the CA, and its key, to the files
+func (a *authority) issue(t *testing.T, certFile string, keyFile string, usage x509.ExtKeyUsage) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.cert, &key.PublicKey, a.key)
+	if err != nil {
+		t.Fatalf("Error in issuing the certificate: %+v\n", err)
+	}
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDer)
+	writePEM(t, certFile, "CERTIFICATE", der)
+}
+
+// handshake connects a client to a server over a pipe, returning the error of the client's handshake
+func handshake(serverConfig *tls.Config, clientConfig *tls.Config) error {
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+	go func() {
+		server := tls.Server(serverConn, serverConfig)
+		server.Handshake()
+		// the client only learns that its certificate was refused once it reads
+		server.Write([]byte("x"))
+		server.Close()
+	}()
+	client := tls.Client(clientConn, clientConfig)
+	if err := client.Handshake(); err != nil {
+		return err
+	}
+	_, err := client.Read(make([]byte, 1))
+	return err
+}
+
+func TestServerAndClient(t *testing.T) {
+	dir := t.TempDir()
+	file := func(name string) string { return filepath.Join(dir, name) }
+	ca := newAuthority(t, file("ca.pem"))
+	ca.issue(t, file("server.pem"), file("server-key.pem"), x509.ExtKeyUsageServerAuth)
+	ca.issue(t, file("client.pem"), file("client-key.pem"), x509.ExtKeyUsageClientAuth)
+
+	if _, err := Server(Config{CAFile: file("ca.pem")}); err != ErrNoCertificate {
+		t.Errorf("Server config without a certificate accepted: %+v\n", err)
+	}
+	if _, err := Client(Config{CertFile: file("client.pem")}); err != ErrNoCertificate {
+		t.Errorf("Client certificate without a key accepted: %+v\n", err)
+	}
+
+	serverConfig, err := Server(Config{CertFile: file("server.pem"), KeyFile: file("server-key.pem"), CAFile: file("ca.pem")})
+	if err != nil {
+		t.Fatalf("Error in creating the server config: %+v\n", err)
+	}
+	clientConfig, err := Client(Config{
+		CertFile:   file("client.pem"),
+		KeyFile:    file("client-key.pem"),
+		CAFile:     file("ca.pem"),
+		ServerName: "localhost",
+	})
+	if err != nil {
+		t.Fatalf("Error in creating the client config: %+v\n", err)
+	}
+	if err := handshake(serverConfig, clientConfig); err != nil {
+		t.Errorf("Error in the mutual TLS handshake: %+v\n", err)
+	}
+
+	anonymousConfig, _ := Client(Config{CAFile: file("ca.pem"), ServerName: "localhost"})
+	if err := handshake(serverConfig, anonymousConfig); err == nil {
+		t.Error("Client without a certificate accepted")
+	}
+
+	wrongNameConfig, _ := Client(Config{CAFile: file("ca.pem"), ServerName: "example.com"})
+	plainServerConfig, _ := Server(Config{CertFile: file("server.pem"), KeyFile: file("server-key.pem")})
+	if err := handshake(plainServerConfig, anonymousConfig); err != nil {
+		t.Errorf("Error in the TLS handshake: %+v\n", err)
+	}
+	if err := handshake(plainServerConfig, wrongNameConfig); err == nil {
+		t.Error("Server certificate for another name accepted")
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	file := func(name string) string { return filepath.Join(dir, name) }
+	ca := newAuthority(t, file("ca.pem"))
+	ca.issue(t, file("server.pem"), file("server-key.pem"), x509.ExtKeyUsageServerAuth)
+	serverConfig, _ := Server(Config{CertFile: file("server.pem"), KeyFile: file("server-key.pem")})
+	clientConfig, _ := Client(Config{CAFile: file("ca.pem"), ServerName: "localhost"})
+
+	// the server's certificate is rotated to one of a new CA, which the client doesn't trust until its CA file
+	// is replaced too
+	rotated_ca := newAuthority(t, file("rotated-ca.pem"))
+	rotated_ca.issue(t, file("server.pem"), file("server-key.pem"), x509.ExtKeyUsageServerAuth)
+	if err := handshake(serverConfig, clientConfig); err == nil {
+		t.Error("Server certificate not reloaded")
+	}
+	writePEM(t, file("ca.pem"), "CERTIFICATE", rotated_ca.cert.Raw)
+	if err := handshake(serverConfig, clientConfig); err != nil {
+		t.Errorf("CA not reloaded: %+v\n", err)
+	}
+
+	// a broken file keeps the previous certificate in use
+	writeFile(t, file("server-key.pem"), []byte("garbage"))
+	if err := handshake(serverConfig, clientConfig); err != nil {
+		t.Errorf("Previous certificate not kept: %+v\n", err)
+	}
+}
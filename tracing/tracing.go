@@ -0,0 +1,97 @@
+// Package tracing sets up OpenTelemetry tracing for the twitter binaries
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+var (
+	ErrUnknownExporter = errors.New("Unrecognized trace exporter")
+	ErrNoDestination   = errors.New("Trace exporter needs a destination")
+)
+
+// Config picks where the spans go
+type Config struct {
+	// Exporter is one of "none", "stdout", "file" or "otlp"
+	Exporter string
+	// Destination is the file spans are appended to for "file", or the collector address for "otlp"
+	Destination string
+}
+
+// fileExporter closes the file once the exporter is shut down
+type fileExporter struct {
+	sdktrace.SpanExporter
+	file *os.File
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if closeErr := e.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func newExporter(config Config) (sdktrace.SpanExporter, error) {
+	switch config.Exporter {
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "file":
+		if config.Destination == "" {
+			return nil, ErrNoDestination
+		}
+		file, err := os.OpenFile(config.Destination, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return &fileExporter{exporter, file}, nil
+	case "otlp":
+		if config.Destination == "" {
+			return nil, ErrNoDestination
+		}
+		return otlptracegrpc.New(context.Background(),
+			otlptracegrpc.WithEndpoint(config.Destination),
+			otlptracegrpc.WithInsecure(),
+		)
+	default:
+		return nil, ErrUnknownExporter
+	}
+}
+
+// New builds the tracer provider of the service and installs it, along with the W3C trace context
+// propagator, as the global ones picked up by the gRPC and HTTP instrumentation.
+// With the "none" exporter trace context is still propagated but no span is recorded.
+// The provider has to be shut down to flush the spans not exported yet.
+func New(serviceName string, config Config) (*sdktrace.TracerProvider, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	}
+	if config.Exporter == "none" || config.Exporter == "" {
+		options = append(options, sdktrace.WithSampler(sdktrace.NeverSample()))
+	} else {
+		exporter, err := newExporter(config)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider, nil
+}
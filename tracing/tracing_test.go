@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New("test", Config{Exporter: "carrier pigeon"}); !errors.Is(err, ErrUnknownExporter) {
+		t.Errorf("Unrecognized exporter accepted: %+v\n", err)
+	}
+	if _, err := New("test", Config{Exporter: "file"}); !errors.Is(err, ErrNoDestination) {
+		t.Errorf("File exporter accepted without a file: %+v\n", err)
+	}
+
+	trace_file := filepath.Join(t.TempDir(), "traces.json")
+	provider, err := New("test", Config{Exporter: "file", Destination: trace_file})
+	if err != nil {
+		t.Fatalf("Error in setting up tracing: %+v\n", err)
+	}
+	ctx, span := otel.Tracer("test").Start(context.Background(), "test span")
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if !strings.Contains(carrier.Get("traceparent"), span.SpanContext().TraceID().String()) {
+		t.Errorf("Trace context not propagated: %+v\n", carrier)
+	}
+	span.End()
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Errorf("Error in shutting down tracing: %+v\n", err)
+	}
+
+	exported, err := os.ReadFile(trace_file)
+	if err != nil {
+		t.Fatalf("Error in reading the trace file: %+v\n", err)
+	}
+	if !strings.Contains(string(exported), `"Name":"test span"`) {
+		t.Errorf("Span not exported to the trace file: %s\n", exported)
+	}
+}
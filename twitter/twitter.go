@@ -1,11 +1,24 @@
 package twitter
 
 import (
+	"bytes"
 	context "context"
+	"errors"
+	"io"
+	"time"
 
 	"github.com/twitter/auth"
+	"github.com/twitter/blobstore"
+	"github.com/twitter/bookmarks"
+	"github.com/twitter/hashtags"
+	"github.com/twitter/impressions"
+	"github.com/twitter/lists"
+	"github.com/twitter/logging"
+	"github.com/twitter/media"
 	models "github.com/twitter/models"
+	"github.com/twitter/polls"
 	"github.com/twitter/posts"
+	"github.com/twitter/search"
 	"github.com/twitter/storage"
 	"github.com/twitter/users"
 	"google.golang.org/grpc"
@@ -15,21 +28,39 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// previousAttemptsKey is the metadata gRPC numbers the retries of an RPC with
+const previousAttemptsKey = "grpc-previous-rpc-attempts"
+
 // Server to be implemented for the defined twitter grpc server
 type Server struct {
 	UnimplementedTwitterServer
-	AuthService    auth.Service
-	StorageService storage.Storage
-	PostService    posts.Service
-	UserService    users.Service
+	AuthService     auth.Service
+	StorageService  storage.Storage
+	PostService     posts.Service
+	UserService     users.Service
+	HashtagService  hashtags.Service
+	SearchService   search.Service
+	MediaService    media.Service
+	BookmarkService bookmarks.Service
+	PollService     polls.Service
+	ListService     lists.Service
+	// ImpressionService counts how often posts returned by GetFeed, GetPost and GetUserProfile are seen
+	ImpressionService impressions.Service
+	// DefaultFeedOptions decide which posts make up the feed when a request doesn't choose
+	DefaultFeedOptions *models.FeedOptions
 }
 
-func (s *Server) HealthCheck(_ context.Context, _ *models.Empty) (*models.Empty, error) {
+// HealthCheck fails with Unavailable when the storage can't be reached, grpc.health.v1.Health reports the
+// same per service
+func (s *Server) HealthCheck(ctx context.Context, _ *models.Empty) (*models.Empty, error) {
+	if err := s.StorageService.Ping(ctx); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
 	return &models.Empty{}, nil
 }
 
 func (s *Server) RegisterUser(ctx context.Context, userToCreate *models.User) (*models.User, error) {
-	createdUser, err := s.UserService.RegisterUser(userToCreate)
+	createdUser, err := s.UserService.RegisterUser(ctx, userToCreate)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -37,7 +68,7 @@ func (s *Server) RegisterUser(ctx context.Context, userToCreate *models.User) (*
 }
 
 func (s *Server) LoginUser(ctx context.Context, userData *models.User) (*models.User, error) {
-	storedUser, err := s.UserService.GetUser(userData)
+	storedUser, err := s.UserService.GetUser(ctx, userData)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -61,7 +92,10 @@ func (s *Server) FollowUser(ctx context.Context, userToFollow *models.User) (*mo
 	if err != nil {
 		return nil, err
 	}
-	err = s.UserService.FollowUser(requestMadeBy, userToFollow)
+	err = s.UserService.FollowUser(ctx, requestMadeBy, userToFollow)
+	if errors.Is(err, users.ErrBlocked) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -73,44 +107,148 @@ func (s *Server) UnFollowUser(ctx context.Context, userToUnFollow *models.User)
 	if err != nil {
 		return nil, err
 	}
-	err = s.UserService.UnFollowUser(requestMadeBy, userToUnFollow)
+	err = s.UserService.UnFollowUser(ctx, requestMadeBy, userToUnFollow)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &models.Empty{}, nil
+}
+
+func (s *Server) BlockUser(ctx context.Context, userToBlock *models.User) (*models.Empty, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if userToBlock.UserName == requestMadeBy.UserName {
+		return nil, status.Error(codes.InvalidArgument, "Users can't block themselves")
+	}
+	err = s.UserService.BlockUser(ctx, requestMadeBy, userToBlock)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &models.Empty{}, nil
+}
+
+func (s *Server) UnBlockUser(ctx context.Context, userToUnBlock *models.User) (*models.Empty, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = s.UserService.UnBlockUser(ctx, requestMadeBy, userToUnBlock)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &models.Empty{}, nil
 }
 
+func (s *Server) SuggestUsers(ctx context.Context, suggestRequest *models.SuggestUsersRequest) (*models.UserSuggestions, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	suggestions, err := s.UserService.SuggestUsers(ctx, requestMadeBy, int(suggestRequest.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &models.UserSuggestions{Suggestions: suggestions}, nil
+}
+
 func (s *Server) CreatePost(ctx context.Context, postToCreate *models.Post) (*models.Post, error) {
 	requestMadeBy, err := s.getUserFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if err := posts.ValidateTTL(postToCreate); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if postToCreate.Poll != nil {
+		if err := polls.Validate(postToCreate.Poll, time.Now()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if err := s.setReplyTo(ctx, postToCreate); err != nil {
+		return nil, err
+	}
+	// reposts are only created through Repost
+	postToCreate.RepostOf = ""
+	postToCreate.RepostedBy = ""
 	postToCreate.PostedBy = requestMadeBy.UserName
 	postToCreate.PostedAt = timestamppb.Now()
-	createdPost, err := s.PostService.CreatePost(postToCreate)
+	postToCreate.Hashtags = hashtags.Extract(postToCreate.Content)
+	createdPost, err := s.PostService.CreatePost(ctx, postToCreate)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "unable to create post")
 	}
-	return createdPost, nil
+	if err := s.indexPost(ctx, createdPost); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to index hashtags of post")
+	}
+	return s.withPollResults(ctx, createdPost, requestMadeBy)
+}
+
+// setReplyTo fills in the author of the post replied to, making sure it exists
+func (s *Server) setReplyTo(ctx context.Context, post *models.Post) error {
+	post.ReplyToUser = ""
+	if post.ReplyTo == "" {
+		return nil
+	}
+	repliedTo, err := s.PostService.GetPost(ctx, post.ReplyTo)
+	if err != nil || repliedTo == nil {
+		return status.Error(codes.NotFound, "Post replied to not found")
+	}
+	post.ReplyToUser = repliedTo.PostedBy
+	return nil
 }
 
-func (s *Server) GetFeed(ctx context.Context, _ *models.Empty) (*models.MultiplePosts, error) {
+// indexPost adds a newly published post to the hashtag index and the reply counts, the search index gets it from the
+// storage's watch
+func (s *Server) indexPost(ctx context.Context, post *models.Post) error {
+	if err := s.HashtagService.IndexPost(ctx, post); err != nil {
+		return err
+	}
+	return s.PostService.AddReply(ctx, post)
+}
+
+func (s *Server) GetFeed(ctx context.Context, feedRequest *models.FeedRequest) (*models.MultiplePosts, error) {
 	requestMadeBy, err := s.getUserFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	completeUserData, err := s.UserService.GetUser(requestMadeBy)
+	completeUserData, err := s.UserService.GetUser(ctx, requestMadeBy)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	followingList := make([]*models.User, 0)
-	for _, curFollowingName := range completeUserData.Follows {
-		followingList = append(followingList, &models.User{UserName: curFollowingName})
+	feedOptions := s.DefaultFeedOptions
+	if feedRequest.Options != nil {
+		feedOptions = feedRequest.Options
+	}
+	feed, err := s.PostService.GetFeed(ctx, posts.FeedAuthors(completeUserData, feedOptions))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "cannot generate feed")
+	}
+	feed, err = s.PostService.ResolveReposts(ctx, posts.FilterFeed(completeUserData, feed, feedOptions))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "cannot generate feed")
 	}
-	feed, err := s.PostService.GetFeed(followingList)
+	blocked, err := s.UserService.GetBlockedUsers(ctx, requestMadeBy)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "cannot generate feed")
 	}
+	feed = posts.WithoutBlocked(feed, blocked)
+	switch feedRequest.Order {
+	case models.FeedOrder_CHRONOLOGICAL:
+	case models.FeedOrder_RANKED:
+		feed, err = s.PostService.RankFeed(ctx, requestMadeBy, feed, time.Now())
+		if err != nil {
+			return nil, status.Error(codes.Internal, "cannot rank feed")
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown feed order: %v", feedRequest.Order)
+	}
+	feed, err = s.PollService.WithResults(ctx, feed, requestMadeBy)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	s.recordImpressions(ctx, feed, requestMadeBy)
 	return &models.MultiplePosts{Posts: feed}, nil
 }
 
@@ -119,7 +257,7 @@ func (s *Server) DeletePost(ctx context.Context, postToDelete *models.Post) (*mo
 	if err != nil {
 		return nil, err
 	}
-	p, err := s.PostService.GetPost(postToDelete.PostID)
+	p, err := s.PostService.GetPost(ctx, postToDelete.PostID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -129,16 +267,113 @@ func (s *Server) DeletePost(ctx context.Context, postToDelete *models.Post) (*mo
 	if p.PostedBy != requestMadeBy.UserName {
 		return nil, status.Error(codes.PermissionDenied, "Only user can delete their posts")
 	}
-	err = s.PostService.DeletePost(postToDelete)
+	err = s.PostService.DeletePost(ctx, p)
 
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	err = s.unindexPost(ctx, p)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &models.Empty{}, nil
 }
 
+func (s *Server) Repost(ctx context.Context, postToRepost *models.Post) (*models.Post, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	original, err := s.PostService.GetPost(ctx, postToRepost.PostID)
+	if err != nil || original == nil {
+		return nil, status.Error(codes.NotFound, "Post not found")
+	}
+	if original.RepostOf != "" {
+		// reposting a repost reposts the post it reposts
+		original, err = s.PostService.GetPost(ctx, original.RepostOf)
+		if err != nil || original == nil {
+			return nil, status.Error(codes.NotFound, "Post not found")
+		}
+	}
+	existingRepost, err := s.PostService.GetRepost(ctx, requestMadeBy, original.PostID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if existingRepost != nil {
+		return nil, status.Error(codes.AlreadyExists, "Post already reposted")
+	}
+	// a repost has no content, so it is left out of the hashtag and search indexes
+	repost, err := s.PostService.CreatePost(ctx, &models.Post{
+		PostedBy: requestMadeBy.UserName,
+		PostedAt: timestamppb.Now(),
+		RepostOf: original.PostID,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "unable to create repost")
+	}
+	resolved, err := s.PostService.ResolveReposts(ctx, []*models.Post{repost})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(resolved) == 0 {
+		return nil, status.Error(codes.NotFound, "Post not found")
+	}
+	return s.withPollResults(ctx, resolved[0], requestMadeBy)
+}
+
+func (s *Server) UnRepost(ctx context.Context, repostedPost *models.Post) (*models.Empty, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repost, err := s.PostService.GetRepost(ctx, requestMadeBy, repostedPost.PostID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if repost == nil {
+		return nil, status.Error(codes.NotFound, "Post not reposted")
+	}
+	if err := s.PostService.DeletePost(ctx, repost); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.unindexPost(ctx, repost); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &models.Empty{}, nil
+}
+
+// unindexPost removes a deleted or expired post from the hashtag index, bookmarks, pins, reply counts,
+// impressions and its poll votes, the search index drops it on the storage's watch
+func (s *Server) unindexPost(ctx context.Context, post *models.Post) error {
+	if err := s.HashtagService.RemovePost(ctx, post); err != nil {
+		return err
+	}
+	if err := s.PostService.RemoveReply(ctx, post); err != nil {
+		return err
+	}
+	if err := s.BookmarkService.RemovePost(ctx, post); err != nil {
+		return err
+	}
+	if err := s.ImpressionService.RemovePost(ctx, post); err != nil {
+		return err
+	}
+	if err := s.PollService.RemovePoll(ctx, post); err != nil {
+		return err
+	}
+	return s.PostService.UnpinPost(ctx, &models.User{UserName: post.PostedBy}, post.PostID)
+}
+
+// RemoveExpiredPosts removes ephemeral posts from the indexes as the storage expires them, until ctx is done
+func (s *Server) RemoveExpiredPosts(ctx context.Context) {
+	for expiredPost := range s.StorageService.PostStore().WatchExpiredPosts(ctx) {
+		if err := s.unindexPost(ctx, expiredPost); err != nil {
+			logging.FromContext(ctx).Error("Unable to unindex expired post", "postId", expiredPost.PostID, "err", err)
+		}
+	}
+}
+
 func (s *Server) GetUser(ctx context.Context, userToGet *models.User) (*models.User, error) {
-	completeUserData, err := s.UserService.GetUser(userToGet)
+	completeUserData, err := s.UserService.GetUser(ctx, userToGet)
 	if err != nil {
 		return nil, err
 	}
@@ -147,18 +382,39 @@ func (s *Server) GetUser(ctx context.Context, userToGet *models.User) (*models.U
 }
 
 func (s *Server) GetUserProfile(ctx context.Context, userToGet *models.User) (*models.UserProfile, error) {
-	completeUserData, err := s.UserService.GetUser(userToGet)
+	completeUserData, err := s.UserService.GetUser(ctx, userToGet)
 	if err != nil {
 		return nil, err
 	}
 	completeUserData.UserPassword = ""
-	postsToReturn, err := s.PostService.GetAllPosts(completeUserData)
+	postsToReturn, err := s.PostService.GetAllPosts(ctx, completeUserData)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	pinnedPostId, err := s.PostService.GetPinnedPost(ctx, completeUserData)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	postsToReturn = posts.PinFirst(postsToReturn, pinnedPostId)
+	if len(postsToReturn) == 0 || postsToReturn[0].PostID != pinnedPostId {
+		// the pinned post is gone
+		pinnedPostId = ""
+	}
+	postsToReturn, err = s.PostService.ResolveReposts(ctx, postsToReturn)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	// the profile can be viewed without logging in, in which case poll results stay hidden until polls close
+	viewer, _ := s.getUserFromContext(ctx)
+	postsToReturn, err = s.PollService.WithResults(ctx, postsToReturn, viewer)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	s.recordImpressions(ctx, postsToReturn, viewer)
 	return &models.UserProfile{
-		User:  completeUserData,
-		Posts: postsToReturn,
+		User:         completeUserData,
+		Posts:        postsToReturn,
+		PinnedPostID: pinnedPostId,
 	}, nil
 }
 
@@ -167,7 +423,7 @@ func (s *Server) GetSelf(ctx context.Context, _ *models.Empty) (*models.User, er
 	if err != nil {
 		return nil, err
 	}
-	completeUserData, err := s.UserService.GetUser(requestMadeBy)
+	completeUserData, err := s.UserService.GetUser(ctx, requestMadeBy)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -180,7 +436,15 @@ func (s *Server) GetMyPosts(ctx context.Context, _ *models.Empty) (*models.Multi
 	if err != nil {
 		return nil, err
 	}
-	postsToReturn, err := s.PostService.GetAllPosts(requestMadeBy)
+	postsToReturn, err := s.PostService.GetAllPosts(ctx, requestMadeBy)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	postsToReturn, err = s.PostService.ResolveReposts(ctx, postsToReturn)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	postsToReturn, err = s.PollService.WithResults(ctx, postsToReturn, requestMadeBy)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -188,15 +452,554 @@ func (s *Server) GetMyPosts(ctx context.Context, _ *models.Empty) (*models.Multi
 }
 
 func (s *Server) GetPost(ctx context.Context, postToGet *models.Post) (*models.Post, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	postToReturn, err := s.PostService.GetPost(ctx, postToGet.PostID)
+	if errors.Is(err, storage.ErrPostNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	s.recordImpressions(ctx, []*models.Post{postToReturn}, requestMadeBy)
+	return s.withPollResults(ctx, postToReturn, requestMadeBy)
+}
+
+func (s *Server) GetHashtagTimeline(ctx context.Context, hashtag *models.Hashtag) (*models.MultiplePosts, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hashtags.Normalize(hashtag.Tag) == "" {
+		return nil, status.Error(codes.InvalidArgument, "Missing hashtag")
+	}
+	timeline, err := s.HashtagService.GetTimeline(ctx, hashtag.Tag)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	blocked, err := s.UserService.GetBlockedUsers(ctx, requestMadeBy)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	timeline = posts.WithoutBlocked(timeline, blocked)
+	timeline, err = s.PollService.WithResults(ctx, timeline, requestMadeBy)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &models.MultiplePosts{Posts: timeline}, nil
+}
+
+func (s *Server) GetTrending(ctx context.Context, trendingRequest *models.TrendingRequest) (*models.TrendingTags, error) {
 	_, err := s.getUserFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	postToReturn, err := s.PostService.GetPost(postToGet.PostID)
+	window := time.Duration(trendingRequest.WindowMinutes) * time.Minute
+	trending, err := s.HashtagService.GetTrending(ctx, window, int(trendingRequest.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &models.TrendingTags{Tags: trending}, nil
+}
+
+func (s *Server) Search(ctx context.Context, searchRequest *models.SearchRequest) (*models.SearchResults, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := s.UserService.GetBlockedUsers(ctx, requestMadeBy)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	offset, limit := int(searchRequest.Offset), int(searchRequest.Limit)
+	postIds, totalPosts := s.SearchService.SearchPosts(searchRequest.Query, blocked, offset, limit)
+	userNames, totalUsers := s.SearchService.SearchUsers(searchRequest.Query, blocked, offset, limit)
+	results := &models.SearchResults{
+		Posts:      make([]*models.Post, 0, len(postIds)),
+		TotalPosts: int32(totalPosts),
+		Users:      make([]*models.User, 0, len(userNames)),
+		TotalUsers: int32(totalUsers),
+	}
+	for _, postId := range postIds {
+		post, err := s.PostService.GetPost(ctx, postId)
+		if err != nil {
+			logging.FromContext(ctx).Debug("Skipping search result", "postId", postId, "err", err)
+			continue
+		}
+		results.Posts = append(results.Posts, post)
+	}
+	results.Posts, err = s.PollService.WithResults(ctx, results.Posts, requestMadeBy)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for _, userName := range userNames {
+		user, err := s.UserService.GetUser(ctx, &models.User{UserName: userName})
+		if err != nil {
+			logging.FromContext(ctx).Debug("Skipping search result", "userName", userName, "err", err)
+			continue
+		}
+		results.Users = append(results.Users, &models.User{
+			UserName:  user.UserName,
+			Followers: user.Followers,
+			Follows:   user.Follows,
+		})
+	}
+	return results, nil
+}
+
+func (s *Server) UploadMedia(stream Twitter_UploadMediaServer) error {
+	_, err := s.getUserFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+	contentType := ""
+	data := &bytes.Buffer{}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if contentType == "" {
+			contentType = chunk.ContentType
+		}
+		if int64(data.Len()+len(chunk.Data)) > s.MediaService.MaxSize() {
+			return status.Errorf(codes.ResourceExhausted, "media is larger than %d bytes", s.MediaService.MaxSize())
+		}
+		data.Write(chunk.Data)
+	}
+	uploadedMedia, err := s.MediaService.Upload(contentType, data.Bytes())
+	if errors.Is(err, media.ErrUnsupportedType) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, media.ErrTooLarge) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return stream.SendAndClose(uploadedMedia)
+}
+
+func (s *Server) GetMedia(mediaToGet *models.Media, stream Twitter_GetMediaServer) error {
+	reader, storedMedia, err := s.MediaService.Open(mediaToGet.MediaID)
+	if errors.Is(err, blobstore.ErrNotFound) || errors.Is(err, blobstore.ErrInvalidKey) {
+		return status.Error(codes.NotFound, "Media not found")
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer reader.Close()
+	buffer := make([]byte, media.ChunkSize)
+	contentType := storedMedia.ContentType
+	for {
+		n, err := reader.Read(buffer)
+		if n > 0 {
+			if sendErr := stream.Send(&models.MediaChunk{ContentType: contentType, Data: buffer[:n]}); sendErr != nil {
+				return sendErr
+			}
+			// only the first chunk carries the content type
+			contentType = ""
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+}
+
+func (s *Server) EditPost(ctx context.Context, postToEdit *models.Post) (*models.Post, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.PostService.GetPost(ctx, postToEdit.PostID)
+	if errors.Is(err, storage.ErrPostNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if p.PostedBy != requestMadeBy.UserName {
+		return nil, status.Error(codes.PermissionDenied, "Only user can edit their posts")
+	}
+	if p.RepostOf != "" {
+		return nil, status.Error(codes.InvalidArgument, "Reposts can't be edited")
+	}
+	editedPost, err := s.PostService.EditPost(ctx, p, postToEdit.Content)
+	if errors.Is(err, posts.ErrEditWindowClosed) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.HashtagService.RemovePost(ctx, p); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.HashtagService.IndexPost(ctx, editedPost); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return s.withPollResults(ctx, editedPost, requestMadeBy)
+}
+
+func (s *Server) GetPostHistory(ctx context.Context, postToGet *models.Post) (*models.PostHistory, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.PostService.GetPostHistory(ctx, postToGet.PostID)
+	if errors.Is(err, storage.ErrPostNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	history.Post, err = s.withPollResults(ctx, history.Post, requestMadeBy)
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (s *Server) SchedulePost(ctx context.Context, postToSchedule *models.ScheduledPost) (*models.ScheduledPost, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if postToSchedule.Post == nil || postToSchedule.PublishAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "Post and publish time are required")
+	}
+	if !postToSchedule.PublishAt.AsTime().After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "Publish time has to be in the future")
+	}
+	if err := posts.ValidateTTL(postToSchedule.Post); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if postToSchedule.Post.Poll != nil {
+		if err := polls.Validate(postToSchedule.Post.Poll, postToSchedule.PublishAt.AsTime()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if err := s.setReplyTo(ctx, postToSchedule.Post); err != nil {
+		return nil, err
+	}
+	postToSchedule.Post.RepostOf = ""
+	postToSchedule.Post.RepostedBy = ""
+	postToSchedule.Post.PostID = ""
+	postToSchedule.Post.PostedBy = requestMadeBy.UserName
+	postToSchedule.Post.Hashtags = hashtags.Extract(postToSchedule.Post.Content)
+	scheduledPost, err := s.PostService.SchedulePost(ctx, postToSchedule)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return scheduledPost, nil
+}
+
+// PublishDuePosts publishes every scheduled post whose publish time has passed.
+// Posts already published by another publisher are skipped, and a post that can't be indexed is logged without
+// holding back the others.
+func (s *Server) PublishDuePosts(ctx context.Context) error {
+	duePosts, err := s.PostService.GetDueScheduledPosts(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, scheduledPost := range duePosts {
+		publishedPost, err := s.PostService.PublishScheduledPost(ctx, scheduledPost)
+		if err != nil {
+			return err
+		}
+		if publishedPost == nil {
+			logging.FromContext(ctx).Debug("Scheduled post already published", "scheduleId", scheduledPost.ScheduleID)
+			continue
+		}
+		if err := s.indexPost(ctx, publishedPost); err != nil {
+			// the post is already published, the others due shouldn't wait for its hashtags
+			logging.FromContext(ctx).Error("Unable to index published post", "postId", publishedPost.PostID, "err", err)
+		}
+	}
+	return nil
+}
+
+func (s *Server) BookmarkPost(ctx context.Context, postToBookmark *models.Post) (*models.Empty, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.BookmarkService.AddBookmark(ctx, requestMadeBy, postToBookmark.PostID); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &models.Empty{}, nil
+}
+
+func (s *Server) RemoveBookmark(ctx context.Context, postToRemove *models.Post) (*models.Empty, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.BookmarkService.RemoveBookmark(ctx, requestMadeBy, postToRemove.PostID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &models.Empty{}, nil
+}
+
+func (s *Server) ListBookmarks(ctx context.Context, bookmarksRequest *models.BookmarksRequest) (*models.Bookmarks, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bookmarkedPosts, total, err := s.BookmarkService.ListBookmarks(ctx, requestMadeBy, int(bookmarksRequest.Offset), int(bookmarksRequest.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	bookmarkedPosts, err = s.PollService.WithResults(ctx, bookmarkedPosts, requestMadeBy)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &models.Bookmarks{Posts: bookmarkedPosts, Total: int32(total)}, nil
+}
+
+func (s *Server) PinPost(ctx context.Context, postToPin *models.Post) (*models.Empty, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.PostService.GetPost(ctx, postToPin.PostID)
+	if err != nil || p == nil {
+		return nil, status.Error(codes.NotFound, "Post not found")
+	}
+	if p.PostedBy != requestMadeBy.UserName {
+		return nil, status.Error(codes.PermissionDenied, "Only user can pin their posts")
+	}
+	if err := s.PostService.PinPost(ctx, p); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &models.Empty{}, nil
+}
+
+func (s *Server) UnpinPost(ctx context.Context, _ *models.Empty) (*models.Empty, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.PostService.UnpinPost(ctx, requestMadeBy, ""); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &models.Empty{}, nil
+}
+
+func (s *Server) VotePoll(ctx context.Context, vote *models.PollVote) (*models.Poll, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.PostService.GetPost(ctx, vote.PostID)
+	if err != nil || p == nil {
+		return nil, status.Error(codes.NotFound, "Post not found")
+	}
+	poll, err := s.PollService.Vote(ctx, p, requestMadeBy, int(vote.Option))
+	switch {
+	case errors.Is(err, polls.ErrNoPoll), errors.Is(err, polls.ErrInvalidOption):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, polls.ErrPollClosed):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, storage.ErrAlreadyVoted):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return poll, nil
+}
+
+func (s *Server) CreateList(ctx context.Context, listToCreate *models.UserList) (*models.UserList, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	createdList, err := s.ListService.CreateList(ctx, requestMadeBy, listToCreate.Name, listToCreate.Private)
+	if err != nil {
+		return nil, listError(err)
+	}
+	return createdList, nil
+}
+
+func (s *Server) GetList(ctx context.Context, listToGet *models.UserList) (*models.UserList, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.ListService.GetList(ctx, requestMadeBy, listToGet.ListID)
+	if err != nil {
+		return nil, listError(err)
+	}
+	return list, nil
+}
+
+func (s *Server) UpdateList(ctx context.Context, listToUpdate *models.UserList) (*models.UserList, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	updatedList, err := s.ListService.UpdateList(ctx, requestMadeBy, listToUpdate)
+	if err != nil {
+		return nil, listError(err)
+	}
+	return updatedList, nil
+}
+
+func (s *Server) DeleteList(ctx context.Context, listToDelete *models.UserList) (*models.Empty, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ListService.DeleteList(ctx, requestMadeBy, listToDelete.ListID); err != nil {
+		return nil, listError(err)
+	}
+	return &models.Empty{}, nil
+}
+
+func (s *Server) GetUserLists(ctx context.Context, owner *models.User) (*models.UserLists, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ownerName := owner.UserName
+	if ownerName == "" {
+		ownerName = requestMadeBy.UserName
+	}
+	userLists, err := s.ListService.GetUserLists(ctx, requestMadeBy, ownerName)
+	if err != nil {
+		return nil, listError(err)
+	}
+	return &models.UserLists{Lists: userLists}, nil
+}
+
+func (s *Server) AddListMember(ctx context.Context, member *models.ListMember) (*models.UserList, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	memberData, err := s.UserService.GetUser(ctx, &models.User{UserName: member.UserName})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	updatedList, err := s.ListService.AddMember(ctx, requestMadeBy, member.ListID, memberData)
+	if err != nil {
+		return nil, listError(err)
+	}
+	return updatedList, nil
+}
+
+func (s *Server) RemoveListMember(ctx context.Context, member *models.ListMember) (*models.UserList, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	updatedList, err := s.ListService.RemoveMember(ctx, requestMadeBy, member.ListID, member.UserName)
+	if err != nil {
+		return nil, listError(err)
+	}
+	return updatedList, nil
+}
+
+func (s *Server) GetListTimeline(ctx context.Context, list *models.UserList) (*models.MultiplePosts, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeline, err := s.ListService.GetTimeline(ctx, requestMadeBy, list.ListID)
+	if err != nil {
+		return nil, listError(err)
+	}
+	timeline, err = s.PostService.ResolveReposts(ctx, timeline)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	blocked, err := s.UserService.GetBlockedUsers(ctx, requestMadeBy)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	timeline = posts.WithoutBlocked(timeline, blocked)
+	timeline, err = s.PollService.WithResults(ctx, timeline, requestMadeBy)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &models.MultiplePosts{Posts: timeline}, nil
+}
+
+func (s *Server) GetPostStats(ctx context.Context, statsRequest *models.PostStatsRequest) (*models.PostStatsList, error) {
+	requestMadeBy, err := s.getUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	postsById := make(map[string]*models.Post, len(statsRequest.PostIDs))
+	for _, postId := range statsRequest.PostIDs {
+		post, err := s.PostService.GetPost(ctx, postId)
+		if err != nil || post == nil {
+			return nil, status.Errorf(codes.NotFound, "Post %s not found", postId)
+		}
+		if post.PostedBy != requestMadeBy.UserName {
+			return nil, status.Error(codes.PermissionDenied, "Only the author can see the stats of a post")
+		}
+		postsById[postId] = post
+	}
+	impressionCounts, err := s.ImpressionService.GetImpressions(ctx, statsRequest.PostIDs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	replyCounts, err := s.PostService.GetReplyCounts(ctx, statsRequest.PostIDs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	stats := make([]*models.PostStats, 0, len(statsRequest.PostIDs))
+	for _, postId := range statsRequest.PostIDs {
+		stats = append(stats, &models.PostStats{
+			PostID:      postId,
+			Impressions: impressionCounts[postId],
+			Likes:       int64(len(postsById[postId].LikedBy)),
+			Replies:     replyCounts[postId],
+		})
+	}
+	return &models.PostStatsList{Stats: stats}, nil
+}
+
+// listError maps the errors of the list service to grpc status errors
+func listError(err error) error {
+	switch {
+	case errors.Is(err, lists.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, lists.ErrNotOwner):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, lists.ErrInvalidName):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, lists.ErrTooManyLists), errors.Is(err, lists.ErrTooManyMembers):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// recordImpressions counts the impressions of the posts served, unless the RPC is a retry. The attempt it repeats
+// reached a server, which may have counted them already.
+func (s *Server) recordImpressions(ctx context.Context, served []*models.Post, viewer *models.User) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if attempts := md.Get(previousAttemptsKey); len(attempts) > 0 && attempts[0] != "0" {
+		return
+	}
+	s.ImpressionService.Record(served, viewer)
+}
+
+// withPollResults fills in the poll of a single post as seen by the viewer
+func (s *Server) withPollResults(ctx context.Context, post *models.Post, viewer *models.User) (*models.Post, error) {
+	withResults, err := s.PollService.WithResults(ctx, []*models.Post{post}, viewer)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return postToReturn, nil
+	return withResults[0], nil
 }
 
 func (s *Server) getUserFromContext(ctx context.Context) (*models.User, error) {
@@ -25,7 +25,7 @@ var File_twitter_proto protoreflect.FileDescriptor
 var file_twitter_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x74, 0x77, 0x69, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
 	0x07, 0x74, 0x77, 0x69, 0x74, 0x74, 0x65, 0x72, 0x1a, 0x0c, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xc8, 0x04, 0x0a, 0x07, 0x54, 0x77, 0x69, 0x74, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xe7, 0x0f, 0x0a, 0x07, 0x54, 0x77, 0x69, 0x74, 0x74,
 	0x65, 0x72, 0x12, 0x2b, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63,
 	0x6b, 0x12, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
 	0x1a, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
@@ -42,37 +42,148 @@ var file_twitter_proto_rawDesc = []byte{
 	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x28, 0x0a, 0x0a,
 	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64,
 	0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
-	0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x46, 0x65, 0x65,
-	0x64, 0x12, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x15, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70,
-	0x6c, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50,
-	0x6f, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x12, 0x25, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0c, 0x2e,
-	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x1a, 0x0c, 0x2e, 0x6d, 0x6f,
-	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x0e, 0x47, 0x65, 0x74,
-	0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x0c, 0x2e, 0x6d, 0x6f,
-	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x1a, 0x13, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
-	0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x26,
-	0x0a, 0x07, 0x47, 0x65, 0x74, 0x53, 0x65, 0x6c, 0x66, 0x12, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
-	0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
-	0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4d, 0x79, 0x50,
-	0x6f, 0x73, 0x74, 0x73, 0x12, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x4d, 0x75, 0x6c,
-	0x74, 0x69, 0x70, 0x6c, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x07, 0x47, 0x65,
-	0x74, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50,
-	0x6f, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73,
-	0x74, 0x42, 0x1c, 0x5a, 0x1a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x74, 0x77, 0x69, 0x74, 0x74, 0x65, 0x72, 0x2f, 0x74, 0x77, 0x69, 0x74, 0x74, 0x65, 0x72, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x46, 0x65, 0x65,
+	0x64, 0x12, 0x13, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x29, 0x0a,
+	0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x1a, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12,
+	0x33, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x1a,
+	0x13, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x26, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x53, 0x65, 0x6c, 0x66, 0x12,
+	0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0c,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x0a,
+	0x47, 0x65, 0x74, 0x4d, 0x79, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x0d, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x73, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x73,
+	0x12, 0x25, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x48, 0x61,
+	0x73, 0x68, 0x74, 0x61, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x0f, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x1a, 0x15,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65,
+	0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x3c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x54, 0x72, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x12, 0x17, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x54, 0x72,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54,
+	0x61, 0x67, 0x73, 0x12, 0x36, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x15, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x0b, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x12, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x0d,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x28, 0x01, 0x12,
+	0x2f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x0d, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x1a, 0x12, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01,
+	0x12, 0x26, 0x0a, 0x08, 0x45, 0x64, 0x69, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x0c, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50,
+	0x6f, 0x73, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x3c, 0x0a,
+	0x0c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x15, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x50, 0x6f, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x0c, 0x42,
+	0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2d, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3c, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x42,
+	0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x12, 0x18, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x73, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x42, 0x6f, 0x6f, 0x6b,
+	0x6d, 0x61, 0x72, 0x6b, 0x73, 0x12, 0x26, 0x0a, 0x07, 0x50, 0x69, 0x6e, 0x50, 0x6f, 0x73, 0x74,
+	0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x1a, 0x0d,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x29, 0x0a,
+	0x09, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x0d, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2a, 0x0a, 0x08, 0x56, 0x6f, 0x74, 0x65,
+	0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f,
+	0x6c, 0x6c, 0x56, 0x6f, 0x74, 0x65, 0x1a, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e,
+	0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x30, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72,
+	0x4c, 0x69, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c,
+	0x69, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2f, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65,
+	0x72, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x1a, 0x11, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x4c, 0x69,
+	0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x10, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x38,
+	0x0a, 0x10, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x12, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x10, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x50,
+	0x6f, 0x73, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65,
+	0x72, 0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x1a,
+	0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2a,
+	0x0a, 0x0b, 0x55, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0c, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x1a, 0x0d, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x44, 0x0a, 0x0c, 0x53, 0x75,
+	0x67, 0x67, 0x65, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x1b, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x24, 0x0a, 0x06, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x08, 0x55, 0x6e, 0x52, 0x65, 0x70, 0x6f,
+	0x73, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74,
+	0x1a, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x3f, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x18, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4c, 0x69, 0x73, 0x74,
+	0x42, 0x1c, 0x5a, 0x1a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74,
+	0x77, 0x69, 0x74, 0x74, 0x65, 0x72, 0x2f, 0x74, 0x77, 0x69, 0x74, 0x74, 0x65, 0x72, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_twitter_proto_goTypes = []interface{}{
-	(*models.Empty)(nil),         // 0: models.Empty
-	(*models.User)(nil),          // 1: models.User
-	(*models.Post)(nil),          // 2: models.Post
-	(*models.MultiplePosts)(nil), // 3: models.MultiplePosts
-	(*models.UserProfile)(nil),   // 4: models.UserProfile
+	(*models.Empty)(nil),               // 0: models.Empty
+	(*models.User)(nil),                // 1: models.User
+	(*models.Post)(nil),                // 2: models.Post
+	(*models.FeedRequest)(nil),         // 3: models.FeedRequest
+	(*models.Hashtag)(nil),             // 4: models.Hashtag
+	(*models.TrendingRequest)(nil),     // 5: models.TrendingRequest
+	(*models.SearchRequest)(nil),       // 6: models.SearchRequest
+	(*models.MediaChunk)(nil),          // 7: models.MediaChunk
+	(*models.Media)(nil),               // 8: models.Media
+	(*models.ScheduledPost)(nil),       // 9: models.ScheduledPost
+	(*models.BookmarksRequest)(nil),    // 10: models.BookmarksRequest
+	(*models.PollVote)(nil),            // 11: models.PollVote
+	(*models.UserList)(nil),            // 12: models.UserList
+	(*models.ListMember)(nil),          // 13: models.ListMember
+	(*models.SuggestUsersRequest)(nil), // 14: models.SuggestUsersRequest
+	(*models.PostStatsRequest)(nil),    // 15: models.PostStatsRequest
+	(*models.MultiplePosts)(nil),       // 16: models.MultiplePosts
+	(*models.UserProfile)(nil),         // 17: models.UserProfile
+	(*models.TrendingTags)(nil),        // 18: models.TrendingTags
+	(*models.SearchResults)(nil),       // 19: models.SearchResults
+	(*models.PostHistory)(nil),         // 20: models.PostHistory
+	(*models.Bookmarks)(nil),           // 21: models.Bookmarks
+	(*models.Poll)(nil),                // 22: models.Poll
+	(*models.UserLists)(nil),           // 23: models.UserLists
+	(*models.UserSuggestions)(nil),     // 24: models.UserSuggestions
+	(*models.PostStatsList)(nil),       // 25: models.PostStatsList
 }
 var file_twitter_proto_depIdxs = []int32{
 	0,  // 0: twitter.Twitter.HealthCheck:input_type -> models.Empty
@@ -81,28 +192,84 @@ var file_twitter_proto_depIdxs = []int32{
 	1,  // 3: twitter.Twitter.FollowUser:input_type -> models.User
 	1,  // 4: twitter.Twitter.UnFollowUser:input_type -> models.User
 	2,  // 5: twitter.Twitter.CreatePost:input_type -> models.Post
-	0,  // 6: twitter.Twitter.GetFeed:input_type -> models.Empty
+	3,  // 6: twitter.Twitter.GetFeed:input_type -> models.FeedRequest
 	2,  // 7: twitter.Twitter.DeletePost:input_type -> models.Post
 	1,  // 8: twitter.Twitter.GetUser:input_type -> models.User
 	1,  // 9: twitter.Twitter.GetUserProfile:input_type -> models.User
 	0,  // 10: twitter.Twitter.GetSelf:input_type -> models.Empty
 	0,  // 11: twitter.Twitter.GetMyPosts:input_type -> models.Empty
 	2,  // 12: twitter.Twitter.GetPost:input_type -> models.Post
-	0,  // 13: twitter.Twitter.HealthCheck:output_type -> models.Empty
-	1,  // 14: twitter.Twitter.RegisterUser:output_type -> models.User
-	1,  // 15: twitter.Twitter.LoginUser:output_type -> models.User
-	0,  // 16: twitter.Twitter.FollowUser:output_type -> models.Empty
-	0,  // 17: twitter.Twitter.UnFollowUser:output_type -> models.Empty
-	2,  // 18: twitter.Twitter.CreatePost:output_type -> models.Post
-	3,  // 19: twitter.Twitter.GetFeed:output_type -> models.MultiplePosts
-	0,  // 20: twitter.Twitter.DeletePost:output_type -> models.Empty
-	1,  // 21: twitter.Twitter.GetUser:output_type -> models.User
-	4,  // 22: twitter.Twitter.GetUserProfile:output_type -> models.UserProfile
-	1,  // 23: twitter.Twitter.GetSelf:output_type -> models.User
-	3,  // 24: twitter.Twitter.GetMyPosts:output_type -> models.MultiplePosts
-	2,  // 25: twitter.Twitter.GetPost:output_type -> models.Post
-	13, // [13:26] is the sub-list for method output_type
-	0,  // [0:13] is the sub-list for method input_type
+	4,  // 13: twitter.Twitter.GetHashtagTimeline:input_type -> models.Hashtag
+	5,  // 14: twitter.Twitter.GetTrending:input_type -> models.TrendingRequest
+	6,  // 15: twitter.Twitter.Search:input_type -> models.SearchRequest
+	7,  // 16: twitter.Twitter.UploadMedia:input_type -> models.MediaChunk
+	8,  // 17: twitter.Twitter.GetMedia:input_type -> models.Media
+	2,  // 18: twitter.Twitter.EditPost:input_type -> models.Post
+	2,  // 19: twitter.Twitter.GetPostHistory:input_type -> models.Post
+	9,  // 20: twitter.Twitter.SchedulePost:input_type -> models.ScheduledPost
+	2,  // 21: twitter.Twitter.BookmarkPost:input_type -> models.Post
+	2,  // 22: twitter.Twitter.RemoveBookmark:input_type -> models.Post
+	10, // 23: twitter.Twitter.ListBookmarks:input_type -> models.BookmarksRequest
+	2,  // 24: twitter.Twitter.PinPost:input_type -> models.Post
+	0,  // 25: twitter.Twitter.UnpinPost:input_type -> models.Empty
+	11, // 26: twitter.Twitter.VotePoll:input_type -> models.PollVote
+	12, // 27: twitter.Twitter.CreateList:input_type -> models.UserList
+	12, // 28: twitter.Twitter.GetList:input_type -> models.UserList
+	12, // 29: twitter.Twitter.UpdateList:input_type -> models.UserList
+	12, // 30: twitter.Twitter.DeleteList:input_type -> models.UserList
+	1,  // 31: twitter.Twitter.GetUserLists:input_type -> models.User
+	13, // 32: twitter.Twitter.AddListMember:input_type -> models.ListMember
+	13, // 33: twitter.Twitter.RemoveListMember:input_type -> models.ListMember
+	12, // 34: twitter.Twitter.GetListTimeline:input_type -> models.UserList
+	1,  // 35: twitter.Twitter.BlockUser:input_type -> models.User
+	1,  // 36: twitter.Twitter.UnBlockUser:input_type -> models.User
+	14, // 37: twitter.Twitter.SuggestUsers:input_type -> models.SuggestUsersRequest
+	2,  // 38: twitter.Twitter.Repost:input_type -> models.Post
+	2,  // 39: twitter.Twitter.UnRepost:input_type -> models.Post
+	15, // 40: twitter.Twitter.GetPostStats:input_type -> models.PostStatsRequest
+	0,  // 41: twitter.Twitter.HealthCheck:output_type -> models.Empty
+	1,  // 42: twitter.Twitter.RegisterUser:output_type -> models.User
+	1,  // 43: twitter.Twitter.LoginUser:output_type -> models.User
+	0,  // 44: twitter.Twitter.FollowUser:output_type -> models.Empty
+	0,  // 45: twitter.Twitter.UnFollowUser:output_type -> models.Empty
+	2,  // 46: twitter.Twitter.CreatePost:output_type -> models.Post
+	16, // 47: twitter.Twitter.GetFeed:output_type -> models.MultiplePosts
+	0,  // 48: twitter.Twitter.DeletePost:output_type -> models.Empty
+	1,  // 49: twitter.Twitter.GetUser:output_type -> models.User
+	17, // 50: twitter.Twitter.GetUserProfile:output_type -> models.UserProfile
+	1,  // 51: twitter.Twitter.GetSelf:output_type -> models.User
+	16, // 52: twitter.Twitter.GetMyPosts:output_type -> models.MultiplePosts
+	2,  // 53: twitter.Twitter.GetPost:output_type -> models.Post
+	16, // 54: twitter.Twitter.GetHashtagTimeline:output_type -> models.MultiplePosts
+	18, // 55: twitter.Twitter.GetTrending:output_type -> models.TrendingTags
+	19, // 56: twitter.Twitter.Search:output_type -> models.SearchResults
+	8,  // 57: twitter.Twitter.UploadMedia:output_type -> models.Media
+	7,  // 58: twitter.Twitter.GetMedia:output_type -> models.MediaChunk
+	2,  // 59: twitter.Twitter.EditPost:output_type -> models.Post
+	20, // 60: twitter.Twitter.GetPostHistory:output_type -> models.PostHistory
+	9,  // 61: twitter.Twitter.SchedulePost:output_type -> models.ScheduledPost
+	0,  // 62: twitter.Twitter.BookmarkPost:output_type -> models.Empty
+	0,  // 63: twitter.Twitter.RemoveBookmark:output_type -> models.Empty
+	21, // 64: twitter.Twitter.ListBookmarks:output_type -> models.Bookmarks
+	0,  // 65: twitter.Twitter.PinPost:output_type -> models.Empty
+	0,  // 66: twitter.Twitter.UnpinPost:output_type -> models.Empty
+	22, // 67: twitter.Twitter.VotePoll:output_type -> models.Poll
+	12, // 68: twitter.Twitter.CreateList:output_type -> models.UserList
+	12, // 69: twitter.Twitter.GetList:output_type -> models.UserList
+	12, // 70: twitter.Twitter.UpdateList:output_type -> models.UserList
+	0,  // 71: twitter.Twitter.DeleteList:output_type -> models.Empty
+	23, // 72: twitter.Twitter.GetUserLists:output_type -> models.UserLists
+	12, // 73: twitter.Twitter.AddListMember:output_type -> models.UserList
+	12, // 74: twitter.Twitter.RemoveListMember:output_type -> models.UserList
+	16, // 75: twitter.Twitter.GetListTimeline:output_type -> models.MultiplePosts
+	0,  // 76: twitter.Twitter.BlockUser:output_type -> models.Empty
+	0,  // 77: twitter.Twitter.UnBlockUser:output_type -> models.Empty
+	24, // 78: twitter.Twitter.SuggestUsers:output_type -> models.UserSuggestions
+	2,  // 79: twitter.Twitter.Repost:output_type -> models.Post
+	0,  // 80: twitter.Twitter.UnRepost:output_type -> models.Empty
+	25, // 81: twitter.Twitter.GetPostStats:output_type -> models.PostStatsList
+	41, // [41:82] is the sub-list for method output_type
+	0,  // [0:41] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
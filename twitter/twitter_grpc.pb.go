@@ -29,13 +29,41 @@ type TwitterClient interface {
 	FollowUser(ctx context.Context, in *models.User, opts ...grpc.CallOption) (*models.Empty, error)
 	UnFollowUser(ctx context.Context, in *models.User, opts ...grpc.CallOption) (*models.Empty, error)
 	CreatePost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Post, error)
-	GetFeed(ctx context.Context, in *models.Empty, opts ...grpc.CallOption) (*models.MultiplePosts, error)
+	GetFeed(ctx context.Context, in *models.FeedRequest, opts ...grpc.CallOption) (*models.MultiplePosts, error)
 	DeletePost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Empty, error)
 	GetUser(ctx context.Context, in *models.User, opts ...grpc.CallOption) (*models.User, error)
 	GetUserProfile(ctx context.Context, in *models.User, opts ...grpc.CallOption) (*models.UserProfile, error)
 	GetSelf(ctx context.Context, in *models.Empty, opts ...grpc.CallOption) (*models.User, error)
 	GetMyPosts(ctx context.Context, in *models.Empty, opts ...grpc.CallOption) (*models.MultiplePosts, error)
 	GetPost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Post, error)
+	GetHashtagTimeline(ctx context.Context, in *models.Hashtag, opts ...grpc.CallOption) (*models.MultiplePosts, error)
+	GetTrending(ctx context.Context, in *models.TrendingRequest, opts ...grpc.CallOption) (*models.TrendingTags, error)
+	Search(ctx context.Context, in *models.SearchRequest, opts ...grpc.CallOption) (*models.SearchResults, error)
+	UploadMedia(ctx context.Context, opts ...grpc.CallOption) (Twitter_UploadMediaClient, error)
+	GetMedia(ctx context.Context, in *models.Media, opts ...grpc.CallOption) (Twitter_GetMediaClient, error)
+	EditPost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Post, error)
+	GetPostHistory(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.PostHistory, error)
+	SchedulePost(ctx context.Context, in *models.ScheduledPost, opts ...grpc.CallOption) (*models.ScheduledPost, error)
+	BookmarkPost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Empty, error)
+	RemoveBookmark(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Empty, error)
+	ListBookmarks(ctx context.Context, in *models.BookmarksRequest, opts ...grpc.CallOption) (*models.Bookmarks, error)
+	PinPost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Empty, error)
+	UnpinPost(ctx context.Context, in *models.Empty, opts ...grpc.CallOption) (*models.Empty, error)
+	VotePoll(ctx context.Context, in *models.PollVote, opts ...grpc.CallOption) (*models.Poll, error)
+	CreateList(ctx context.Context, in *models.UserList, opts ...grpc.CallOption) (*models.UserList, error)
+	GetList(ctx context.Context, in *models.UserList, opts ...grpc.CallOption) (*models.UserList, error)
+	UpdateList(ctx context.Context, in *models.UserList, opts ...grpc.CallOption) (*models.UserList, error)
+	DeleteList(ctx context.Context, in *models.UserList, opts ...grpc.CallOption) (*models.Empty, error)
+	GetUserLists(ctx context.Context, in *models.User, opts ...grpc.CallOption) (*models.UserLists, error)
+	AddListMember(ctx context.Context, in *models.ListMember, opts ...grpc.CallOption) (*models.UserList, error)
+	RemoveListMember(ctx context.Context, in *models.ListMember, opts ...grpc.CallOption) (*models.UserList, error)
+	GetListTimeline(ctx context.Context, in *models.UserList, opts ...grpc.CallOption) (*models.MultiplePosts, error)
+	BlockUser(ctx context.Context, in *models.User, opts ...grpc.CallOption) (*models.Empty, error)
+	UnBlockUser(ctx context.Context, in *models.User, opts ...grpc.CallOption) (*models.Empty, error)
+	SuggestUsers(ctx context.Context, in *models.SuggestUsersRequest, opts ...grpc.CallOption) (*models.UserSuggestions, error)
+	Repost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Post, error)
+	UnRepost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Empty, error)
+	GetPostStats(ctx context.Context, in *models.PostStatsRequest, opts ...grpc.CallOption) (*models.PostStatsList, error)
 }
 
 type twitterClient struct {
@@ -100,7 +128,7 @@ func (c *twitterClient) CreatePost(ctx context.Context, in *models.Post, opts ..
 	return out, nil
 }
 
-func (c *twitterClient) GetFeed(ctx context.Context, in *models.Empty, opts ...grpc.CallOption) (*models.MultiplePosts, error) {
+func (c *twitterClient) GetFeed(ctx context.Context, in *models.FeedRequest, opts ...grpc.CallOption) (*models.MultiplePosts, error) {
 	out := new(models.MultiplePosts)
 	err := c.cc.Invoke(ctx, "/twitter.Twitter/GetFeed", in, out, opts...)
 	if err != nil {
@@ -163,6 +191,306 @@ func (c *twitterClient) GetPost(ctx context.Context, in *models.Post, opts ...gr
 	return out, nil
 }
 
+func (c *twitterClient) GetHashtagTimeline(ctx context.Context, in *models.Hashtag, opts ...grpc.CallOption) (*models.MultiplePosts, error) {
+	out := new(models.MultiplePosts)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/GetHashtagTimeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) GetTrending(ctx context.Context, in *models.TrendingRequest, opts ...grpc.CallOption) (*models.TrendingTags, error) {
+	out := new(models.TrendingTags)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/GetTrending", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) Search(ctx context.Context, in *models.SearchRequest, opts ...grpc.CallOption) (*models.SearchResults, error) {
+	out := new(models.SearchResults)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) UploadMedia(ctx context.Context, opts ...grpc.CallOption) (Twitter_UploadMediaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Twitter_ServiceDesc.Streams[0], "/twitter.Twitter/UploadMedia", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &twitterUploadMediaClient{stream}
+	return x, nil
+}
+
+type Twitter_UploadMediaClient interface {
+	Send(*models.MediaChunk) error
+	CloseAndRecv() (*models.Media, error)
+	grpc.ClientStream
+}
+
+type twitterUploadMediaClient struct {
+	grpc.ClientStream
+}
+
+func (x *twitterUploadMediaClient) Send(m *models.MediaChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *twitterUploadMediaClient) CloseAndRecv() (*models.Media, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(models.Media)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *twitterClient) GetMedia(ctx context.Context, in *models.Media, opts ...grpc.CallOption) (Twitter_GetMediaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Twitter_ServiceDesc.Streams[1], "/twitter.Twitter/GetMedia", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &twitterGetMediaClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Twitter_GetMediaClient interface {
+	Recv() (*models.MediaChunk, error)
+	grpc.ClientStream
+}
+
+type twitterGetMediaClient struct {
+	grpc.ClientStream
+}
+
+func (x *twitterGetMediaClient) Recv() (*models.MediaChunk, error) {
+	m := new(models.MediaChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *twitterClient) EditPost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Post, error) {
+	out := new(models.Post)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/EditPost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) GetPostHistory(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.PostHistory, error) {
+	out := new(models.PostHistory)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/GetPostHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) SchedulePost(ctx context.Context, in *models.ScheduledPost, opts ...grpc.CallOption) (*models.ScheduledPost, error) {
+	out := new(models.ScheduledPost)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/SchedulePost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) BookmarkPost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Empty, error) {
+	out := new(models.Empty)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/BookmarkPost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) RemoveBookmark(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Empty, error) {
+	out := new(models.Empty)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/RemoveBookmark", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) ListBookmarks(ctx context.Context, in *models.BookmarksRequest, opts ...grpc.CallOption) (*models.Bookmarks, error) {
+	out := new(models.Bookmarks)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/ListBookmarks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) PinPost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Empty, error) {
+	out := new(models.Empty)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/PinPost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) UnpinPost(ctx context.Context, in *models.Empty, opts ...grpc.CallOption) (*models.Empty, error) {
+	out := new(models.Empty)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/UnpinPost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) VotePoll(ctx context.Context, in *models.PollVote, opts ...grpc.CallOption) (*models.Poll, error) {
+	out := new(models.Poll)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/VotePoll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) CreateList(ctx context.Context, in *models.UserList, opts ...grpc.CallOption) (*models.UserList, error) {
+	out := new(models.UserList)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/CreateList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) GetList(ctx context.Context, in *models.UserList, opts ...grpc.CallOption) (*models.UserList, error) {
+	out := new(models.UserList)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/GetList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) UpdateList(ctx context.Context, in *models.UserList, opts ...grpc.CallOption) (*models.UserList, error) {
+	out := new(models.UserList)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/UpdateList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) DeleteList(ctx context.Context, in *models.UserList, opts ...grpc.CallOption) (*models.Empty, error) {
+	out := new(models.Empty)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/DeleteList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) GetUserLists(ctx context.Context, in *models.User, opts ...grpc.CallOption) (*models.UserLists, error) {
+	out := new(models.UserLists)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/GetUserLists", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) AddListMember(ctx context.Context, in *models.ListMember, opts ...grpc.CallOption) (*models.UserList, error) {
+	out := new(models.UserList)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/AddListMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) RemoveListMember(ctx context.Context, in *models.ListMember, opts ...grpc.CallOption) (*models.UserList, error) {
+	out := new(models.UserList)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/RemoveListMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) GetListTimeline(ctx context.Context, in *models.UserList, opts ...grpc.CallOption) (*models.MultiplePosts, error) {
+	out := new(models.MultiplePosts)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/GetListTimeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) BlockUser(ctx context.Context, in *models.User, opts ...grpc.CallOption) (*models.Empty, error) {
+	out := new(models.Empty)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/BlockUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) UnBlockUser(ctx context.Context, in *models.User, opts ...grpc.CallOption) (*models.Empty, error) {
+	out := new(models.Empty)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/UnBlockUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) SuggestUsers(ctx context.Context, in *models.SuggestUsersRequest, opts ...grpc.CallOption) (*models.UserSuggestions, error) {
+	out := new(models.UserSuggestions)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/SuggestUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) Repost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Post, error) {
+	out := new(models.Post)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/Repost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) UnRepost(ctx context.Context, in *models.Post, opts ...grpc.CallOption) (*models.Empty, error) {
+	out := new(models.Empty)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/UnRepost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *twitterClient) GetPostStats(ctx context.Context, in *models.PostStatsRequest, opts ...grpc.CallOption) (*models.PostStatsList, error) {
+	out := new(models.PostStatsList)
+	err := c.cc.Invoke(ctx, "/twitter.Twitter/GetPostStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TwitterServer is the server API for Twitter service.
 // All implementations must embed UnimplementedTwitterServer
 // for forward compatibility
@@ -173,13 +501,41 @@ type TwitterServer interface {
 	FollowUser(context.Context, *models.User) (*models.Empty, error)
 	UnFollowUser(context.Context, *models.User) (*models.Empty, error)
 	CreatePost(context.Context, *models.Post) (*models.Post, error)
-	GetFeed(context.Context, *models.Empty) (*models.MultiplePosts, error)
+	GetFeed(context.Context, *models.FeedRequest) (*models.MultiplePosts, error)
 	DeletePost(context.Context, *models.Post) (*models.Empty, error)
 	GetUser(context.Context, *models.User) (*models.User, error)
 	GetUserProfile(context.Context, *models.User) (*models.UserProfile, error)
 	GetSelf(context.Context, *models.Empty) (*models.User, error)
 	GetMyPosts(context.Context, *models.Empty) (*models.MultiplePosts, error)
 	GetPost(context.Context, *models.Post) (*models.Post, error)
+	GetHashtagTimeline(context.Context, *models.Hashtag) (*models.MultiplePosts, error)
+	GetTrending(context.Context, *models.TrendingRequest) (*models.TrendingTags, error)
+	Search(context.Context, *models.SearchRequest) (*models.SearchResults, error)
+	UploadMedia(Twitter_UploadMediaServer) error
+	GetMedia(*models.Media, Twitter_GetMediaServer) error
+	EditPost(context.Context, *models.Post) (*models.Post, error)
+	GetPostHistory(context.Context, *models.Post) (*models.PostHistory, error)
+	SchedulePost(context.Context, *models.ScheduledPost) (*models.ScheduledPost, error)
+	BookmarkPost(context.Context, *models.Post) (*models.Empty, error)
+	RemoveBookmark(context.Context, *models.Post) (*models.Empty, error)
+	ListBookmarks(context.Context, *models.BookmarksRequest) (*models.Bookmarks, error)
+	PinPost(context.Context, *models.Post) (*models.Empty, error)
+	UnpinPost(context.Context, *models.Empty) (*models.Empty, error)
+	VotePoll(context.Context, *models.PollVote) (*models.Poll, error)
+	CreateList(context.Context, *models.UserList) (*models.UserList, error)
+	GetList(context.Context, *models.UserList) (*models.UserList, error)
+	UpdateList(context.Context, *models.UserList) (*models.UserList, error)
+	DeleteList(context.Context, *models.UserList) (*models.Empty, error)
+	GetUserLists(context.Context, *models.User) (*models.UserLists, error)
+	AddListMember(context.Context, *models.ListMember) (*models.UserList, error)
+	RemoveListMember(context.Context, *models.ListMember) (*models.UserList, error)
+	GetListTimeline(context.Context, *models.UserList) (*models.MultiplePosts, error)
+	BlockUser(context.Context, *models.User) (*models.Empty, error)
+	UnBlockUser(context.Context, *models.User) (*models.Empty, error)
+	SuggestUsers(context.Context, *models.SuggestUsersRequest) (*models.UserSuggestions, error)
+	Repost(context.Context, *models.Post) (*models.Post, error)
+	UnRepost(context.Context, *models.Post) (*models.Empty, error)
+	GetPostStats(context.Context, *models.PostStatsRequest) (*models.PostStatsList, error)
 	mustEmbedUnimplementedTwitterServer()
 }
 
@@ -205,7 +561,7 @@ func (UnimplementedTwitterServer) UnFollowUser(context.Context, *models.User) (*
 func (UnimplementedTwitterServer) CreatePost(context.Context, *models.Post) (*models.Post, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreatePost not implemented")
 }
-func (UnimplementedTwitterServer) GetFeed(context.Context, *models.Empty) (*models.MultiplePosts, error) {
+func (UnimplementedTwitterServer) GetFeed(context.Context, *models.FeedRequest) (*models.MultiplePosts, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFeed not implemented")
 }
 func (UnimplementedTwitterServer) DeletePost(context.Context, *models.Post) (*models.Empty, error) {
@@ -226,6 +582,90 @@ func (UnimplementedTwitterServer) GetMyPosts(context.Context, *models.Empty) (*m
 func (UnimplementedTwitterServer) GetPost(context.Context, *models.Post) (*models.Post, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPost not implemented")
 }
+func (UnimplementedTwitterServer) GetHashtagTimeline(context.Context, *models.Hashtag) (*models.MultiplePosts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHashtagTimeline not implemented")
+}
+func (UnimplementedTwitterServer) GetTrending(context.Context, *models.TrendingRequest) (*models.TrendingTags, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrending not implemented")
+}
+func (UnimplementedTwitterServer) Search(context.Context, *models.SearchRequest) (*models.SearchResults, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedTwitterServer) UploadMedia(Twitter_UploadMediaServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadMedia not implemented")
+}
+func (UnimplementedTwitterServer) GetMedia(*models.Media, Twitter_GetMediaServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetMedia not implemented")
+}
+func (UnimplementedTwitterServer) EditPost(context.Context, *models.Post) (*models.Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditPost not implemented")
+}
+func (UnimplementedTwitterServer) GetPostHistory(context.Context, *models.Post) (*models.PostHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostHistory not implemented")
+}
+func (UnimplementedTwitterServer) SchedulePost(context.Context, *models.ScheduledPost) (*models.ScheduledPost, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulePost not implemented")
+}
+func (UnimplementedTwitterServer) BookmarkPost(context.Context, *models.Post) (*models.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BookmarkPost not implemented")
+}
+func (UnimplementedTwitterServer) RemoveBookmark(context.Context, *models.Post) (*models.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveBookmark not implemented")
+}
+func (UnimplementedTwitterServer) ListBookmarks(context.Context, *models.BookmarksRequest) (*models.Bookmarks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBookmarks not implemented")
+}
+func (UnimplementedTwitterServer) PinPost(context.Context, *models.Post) (*models.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinPost not implemented")
+}
+func (UnimplementedTwitterServer) UnpinPost(context.Context, *models.Empty) (*models.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpinPost not implemented")
+}
+func (UnimplementedTwitterServer) VotePoll(context.Context, *models.PollVote) (*models.Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VotePoll not implemented")
+}
+func (UnimplementedTwitterServer) CreateList(context.Context, *models.UserList) (*models.UserList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateList not implemented")
+}
+func (UnimplementedTwitterServer) GetList(context.Context, *models.UserList) (*models.UserList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedTwitterServer) UpdateList(context.Context, *models.UserList) (*models.UserList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateList not implemented")
+}
+func (UnimplementedTwitterServer) DeleteList(context.Context, *models.UserList) (*models.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteList not implemented")
+}
+func (UnimplementedTwitterServer) GetUserLists(context.Context, *models.User) (*models.UserLists, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserLists not implemented")
+}
+func (UnimplementedTwitterServer) AddListMember(context.Context, *models.ListMember) (*models.UserList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddListMember not implemented")
+}
+func (UnimplementedTwitterServer) RemoveListMember(context.Context, *models.ListMember) (*models.UserList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveListMember not implemented")
+}
+func (UnimplementedTwitterServer) GetListTimeline(context.Context, *models.UserList) (*models.MultiplePosts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetListTimeline not implemented")
+}
+func (UnimplementedTwitterServer) BlockUser(context.Context, *models.User) (*models.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedTwitterServer) UnBlockUser(context.Context, *models.User) (*models.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnBlockUser not implemented")
+}
+func (UnimplementedTwitterServer) SuggestUsers(context.Context, *models.SuggestUsersRequest) (*models.UserSuggestions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestUsers not implemented")
+}
+func (UnimplementedTwitterServer) Repost(context.Context, *models.Post) (*models.Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Repost not implemented")
+}
+func (UnimplementedTwitterServer) UnRepost(context.Context, *models.Post) (*models.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnRepost not implemented")
+}
+func (UnimplementedTwitterServer) GetPostStats(context.Context, *models.PostStatsRequest) (*models.PostStatsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostStats not implemented")
+}
 func (UnimplementedTwitterServer) mustEmbedUnimplementedTwitterServer() {}
 
 // UnsafeTwitterServer may be embedded to opt out of forward compatibility for this service.
@@ -348,7 +788,7 @@ func _Twitter_CreatePost_Handler(srv interface{}, ctx context.Context, dec func(
 }
 
 func _Twitter_GetFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(models.Empty)
+	in := new(models.FeedRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -360,7 +800,7 @@ func _Twitter_GetFeed_Handler(srv interface{}, ctx context.Context, dec func(int
 		FullMethod: "/twitter.Twitter/GetFeed",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TwitterServer).GetFeed(ctx, req.(*models.Empty))
+		return srv.(TwitterServer).GetFeed(ctx, req.(*models.FeedRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -473,31 +913,546 @@ func _Twitter_GetPost_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
-// Twitter_ServiceDesc is the grpc.ServiceDesc for Twitter service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Twitter_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "twitter.Twitter",
-	HandlerType: (*TwitterServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "HealthCheck",
-			Handler:    _Twitter_HealthCheck_Handler,
-		},
-		{
-			MethodName: "RegisterUser",
-			Handler:    _Twitter_RegisterUser_Handler,
-		},
-		{
-			MethodName: "LoginUser",
-			Handler:    _Twitter_LoginUser_Handler,
-		},
-		{
-			MethodName: "FollowUser",
-			Handler:    _Twitter_FollowUser_Handler,
-		},
-		{
-			MethodName: "UnFollowUser",
+func _Twitter_GetHashtagTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.Hashtag)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).GetHashtagTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/GetHashtagTimeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).GetHashtagTimeline(ctx, req.(*models.Hashtag))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_GetTrending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.TrendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).GetTrending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/GetTrending",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).GetTrending(ctx, req.(*models.TrendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).Search(ctx, req.(*models.SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_UploadMedia_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TwitterServer).UploadMedia(&twitterUploadMediaServer{stream})
+}
+
+type Twitter_UploadMediaServer interface {
+	SendAndClose(*models.Media) error
+	Recv() (*models.MediaChunk, error)
+	grpc.ServerStream
+}
+
+type twitterUploadMediaServer struct {
+	grpc.ServerStream
+}
+
+func (x *twitterUploadMediaServer) SendAndClose(m *models.Media) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *twitterUploadMediaServer) Recv() (*models.MediaChunk, error) {
+	m := new(models.MediaChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Twitter_GetMedia_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(models.Media)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TwitterServer).GetMedia(m, &twitterGetMediaServer{stream})
+}
+
+type Twitter_GetMediaServer interface {
+	Send(*models.MediaChunk) error
+	grpc.ServerStream
+}
+
+type twitterGetMediaServer struct {
+	grpc.ServerStream
+}
+
+func (x *twitterGetMediaServer) Send(m *models.MediaChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Twitter_EditPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.Post)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).EditPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/EditPost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).EditPost(ctx, req.(*models.Post))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_GetPostHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.Post)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).GetPostHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/GetPostHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).GetPostHistory(ctx, req.(*models.Post))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_SchedulePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.ScheduledPost)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).SchedulePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/SchedulePost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).SchedulePost(ctx, req.(*models.ScheduledPost))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_BookmarkPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.Post)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).BookmarkPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/BookmarkPost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).BookmarkPost(ctx, req.(*models.Post))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_RemoveBookmark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.Post)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).RemoveBookmark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/RemoveBookmark",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).RemoveBookmark(ctx, req.(*models.Post))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_ListBookmarks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.BookmarksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).ListBookmarks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/ListBookmarks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).ListBookmarks(ctx, req.(*models.BookmarksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_PinPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.Post)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).PinPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/PinPost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).PinPost(ctx, req.(*models.Post))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_UnpinPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).UnpinPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/UnpinPost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).UnpinPost(ctx, req.(*models.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_VotePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.PollVote)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).VotePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/VotePoll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).VotePoll(ctx, req.(*models.PollVote))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_CreateList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.UserList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).CreateList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/CreateList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).CreateList(ctx, req.(*models.UserList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_GetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.UserList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).GetList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/GetList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).GetList(ctx, req.(*models.UserList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_UpdateList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.UserList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).UpdateList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/UpdateList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).UpdateList(ctx, req.(*models.UserList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_DeleteList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.UserList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).DeleteList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/DeleteList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).DeleteList(ctx, req.(*models.UserList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_GetUserLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).GetUserLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/GetUserLists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).GetUserLists(ctx, req.(*models.User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_AddListMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.ListMember)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).AddListMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/AddListMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).AddListMember(ctx, req.(*models.ListMember))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_RemoveListMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.ListMember)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).RemoveListMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/RemoveListMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).RemoveListMember(ctx, req.(*models.ListMember))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_GetListTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.UserList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).GetListTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/GetListTimeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).GetListTimeline(ctx, req.(*models.UserList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/BlockUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).BlockUser(ctx, req.(*models.User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_UnBlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).UnBlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/UnBlockUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).UnBlockUser(ctx, req.(*models.User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_SuggestUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.SuggestUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).SuggestUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/SuggestUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).SuggestUsers(ctx, req.(*models.SuggestUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_Repost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.Post)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).Repost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/Repost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).Repost(ctx, req.(*models.Post))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_UnRepost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.Post)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).UnRepost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/UnRepost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).UnRepost(ctx, req.(*models.Post))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Twitter_GetPostStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(models.PostStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TwitterServer).GetPostStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/twitter.Twitter/GetPostStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TwitterServer).GetPostStats(ctx, req.(*models.PostStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Twitter_ServiceDesc is the grpc.ServiceDesc for Twitter service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Twitter_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "twitter.Twitter",
+	HandlerType: (*TwitterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "HealthCheck",
+			Handler:    _Twitter_HealthCheck_Handler,
+		},
+		{
+			MethodName: "RegisterUser",
+			Handler:    _Twitter_RegisterUser_Handler,
+		},
+		{
+			MethodName: "LoginUser",
+			Handler:    _Twitter_LoginUser_Handler,
+		},
+		{
+			MethodName: "FollowUser",
+			Handler:    _Twitter_FollowUser_Handler,
+		},
+		{
+			MethodName: "UnFollowUser",
 			Handler:    _Twitter_UnFollowUser_Handler,
 		},
 		{
@@ -532,7 +1487,122 @@ var Twitter_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPost",
 			Handler:    _Twitter_GetPost_Handler,
 		},
+		{
+			MethodName: "GetHashtagTimeline",
+			Handler:    _Twitter_GetHashtagTimeline_Handler,
+		},
+		{
+			MethodName: "GetTrending",
+			Handler:    _Twitter_GetTrending_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _Twitter_Search_Handler,
+		},
+		{
+			MethodName: "EditPost",
+			Handler:    _Twitter_EditPost_Handler,
+		},
+		{
+			MethodName: "GetPostHistory",
+			Handler:    _Twitter_GetPostHistory_Handler,
+		},
+		{
+			MethodName: "SchedulePost",
+			Handler:    _Twitter_SchedulePost_Handler,
+		},
+		{
+			MethodName: "BookmarkPost",
+			Handler:    _Twitter_BookmarkPost_Handler,
+		},
+		{
+			MethodName: "RemoveBookmark",
+			Handler:    _Twitter_RemoveBookmark_Handler,
+		},
+		{
+			MethodName: "ListBookmarks",
+			Handler:    _Twitter_ListBookmarks_Handler,
+		},
+		{
+			MethodName: "PinPost",
+			Handler:    _Twitter_PinPost_Handler,
+		},
+		{
+			MethodName: "UnpinPost",
+			Handler:    _Twitter_UnpinPost_Handler,
+		},
+		{
+			MethodName: "VotePoll",
+			Handler:    _Twitter_VotePoll_Handler,
+		},
+		{
+			MethodName: "CreateList",
+			Handler:    _Twitter_CreateList_Handler,
+		},
+		{
+			MethodName: "GetList",
+			Handler:    _Twitter_GetList_Handler,
+		},
+		{
+			MethodName: "UpdateList",
+			Handler:    _Twitter_UpdateList_Handler,
+		},
+		{
+			MethodName: "DeleteList",
+			Handler:    _Twitter_DeleteList_Handler,
+		},
+		{
+			MethodName: "GetUserLists",
+			Handler:    _Twitter_GetUserLists_Handler,
+		},
+		{
+			MethodName: "AddListMember",
+			Handler:    _Twitter_AddListMember_Handler,
+		},
+		{
+			MethodName: "RemoveListMember",
+			Handler:    _Twitter_RemoveListMember_Handler,
+		},
+		{
+			MethodName: "GetListTimeline",
+			Handler:    _Twitter_GetListTimeline_Handler,
+		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _Twitter_BlockUser_Handler,
+		},
+		{
+			MethodName: "UnBlockUser",
+			Handler:    _Twitter_UnBlockUser_Handler,
+		},
+		{
+			MethodName: "SuggestUsers",
+			Handler:    _Twitter_SuggestUsers_Handler,
+		},
+		{
+			MethodName: "Repost",
+			Handler:    _Twitter_Repost_Handler,
+		},
+		{
+			MethodName: "UnRepost",
+			Handler:    _Twitter_UnRepost_Handler,
+		},
+		{
+			MethodName: "GetPostStats",
+			Handler:    _Twitter_GetPostStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadMedia",
+			Handler:       _Twitter_UploadMedia_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetMedia",
+			Handler:       _Twitter_GetMedia_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "twitter.proto",
 }
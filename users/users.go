@@ -1,16 +1,40 @@
 package users
 
 import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
 	"github.com/twitter/auth"
 	"github.com/twitter/models"
 	"github.com/twitter/storage"
 )
 
+const (
+	DefaultSuggestionLimit = 5
+	MaxSuggestionLimit     = 50
+	// how many of the mutual connections are named along with a suggestion
+	maxNamedMutuals = 3
+)
+
+// ErrBlocked is returned when a user tries to follow someone they blocked or who blocked them
+var ErrBlocked = errors.New("Blocked users can't follow each other")
+
 type Service interface {
-	GetUser(*models.User) (*models.User, error)
-	RegisterUser(*models.User) (*models.User, error)
-	FollowUser(*models.User, *models.User) error
-	UnFollowUser(*models.User, *models.User) error
+	GetUser(ctx context.Context, user *models.User) (*models.User, error)
+	RegisterUser(ctx context.Context, user *models.User) (*models.User, error)
+	// FollowUser fails with ErrBlocked if either of the users blocked the other
+	FollowUser(ctx context.Context, user *models.User, target *models.User) error
+	UnFollowUser(ctx context.Context, user *models.User, target *models.User) error
+	// BlockUser blocks the user and removes the follow relations between the two
+	BlockUser(ctx context.Context, user *models.User, target *models.User) error
+	UnBlockUser(ctx context.Context, user *models.User, target *models.User) error
+	// GetBlockedUsers returns the usernames the user blocked, whose posts are left out of what the user is shown
+	GetBlockedUsers(ctx context.Context, user *models.User) ([]string, error)
+	// SuggestUsers ranks the people followed by the people the user follows by how many of them follow each.
+	// People the user already follows, has blocked or was blocked by are left out.
+	SuggestUsers(ctx context.Context, user *models.User, limit int) ([]*models.UserSuggestion, error)
 }
 
 type UserService struct {
@@ -18,22 +42,157 @@ type UserService struct {
 	db          storage.Storage
 }
 
-func (us *UserService) GetUser(userToGet *models.User) (*models.User, error) {
-	return us.db.UserStore().GetUser(userToGet.UserName)
+func (us *UserService) GetUser(ctx context.Context, userToGet *models.User) (*models.User, error) {
+	return us.db.UserStore().GetUser(ctx, userToGet.UserName)
 }
 
-func (us *UserService) RegisterUser(newUser *models.User) (*models.User, error) {
+func (us *UserService) RegisterUser(ctx context.Context, newUser *models.User) (*models.User, error) {
 	hashedPassword := us.authService.SecureValue(newUser.UserPassword)
 	newUser.UserPassword = hashedPassword
-	return us.db.UserStore().AddUser(newUser)
+	return us.db.UserStore().AddUser(ctx, newUser)
+}
+
+func (us *UserService) FollowUser(ctx context.Context, curUser *models.User, userToFollow *models.User) error {
+	blocked, err := us.blocks(ctx, curUser.UserName, userToFollow.UserName)
+	if err != nil {
+		return err
+	}
+	if !blocked {
+		blocked, err = us.blocks(ctx, userToFollow.UserName, curUser.UserName)
+		if err != nil {
+			return err
+		}
+	}
+	if blocked {
+		return ErrBlocked
+	}
+	return us.db.UserStore().FollowUser(ctx, curUser, userToFollow)
+}
+
+// blocks reports whether the user blocked the target
+func (us *UserService) blocks(ctx context.Context, userName string, target string) (bool, error) {
+	blocked, err := us.db.UserStore().GetBlockedUsers(ctx, userName)
+	if err != nil {
+		return false, err
+	}
+	for _, blockedName := range blocked {
+		if blockedName == target {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (us *UserService) UnFollowUser(ctx context.Context, curUser *models.User, userToUnFollow *models.User) error {
+	return us.db.UserStore().UnFollowUser(ctx, curUser, userToUnFollow)
+}
+
+func (us *UserService) BlockUser(ctx context.Context, curUser *models.User, userToBlock *models.User) error {
+	if err := us.db.UserStore().BlockUser(ctx, curUser.UserName, userToBlock.UserName); err != nil {
+		return err
+	}
+	if err := us.db.UserStore().UnFollowUser(ctx, curUser, userToBlock); err != nil {
+		return err
+	}
+	return us.db.UserStore().UnFollowUser(ctx, userToBlock, curUser)
 }
 
-func (us *UserService) FollowUser(curUser *models.User, userToFollow *models.User) error {
-	return us.db.UserStore().FollowUser(curUser, userToFollow)
+func (us *UserService) UnBlockUser(ctx context.Context, curUser *models.User, userToUnBlock *models.User) error {
+	return us.db.UserStore().UnBlockUser(ctx, curUser.UserName, userToUnBlock.UserName)
 }
 
-func (us *UserService) UnFollowUser(curUser *models.User, userToUnFollow *models.User) error {
-	return us.db.UserStore().UnFollowUser(curUser, userToUnFollow)
+func (us *UserService) GetBlockedUsers(ctx context.Context, user *models.User) ([]string, error) {
+	return us.db.UserStore().GetBlockedUsers(ctx, user.UserName)
+}
+
+func (us *UserService) SuggestUsers(ctx context.Context, user *models.User, limit int) ([]*models.UserSuggestion, error) {
+	if limit <= 0 {
+		limit = DefaultSuggestionLimit
+	}
+	if limit > MaxSuggestionLimit {
+		limit = MaxSuggestionLimit
+	}
+	completeUserData, err := us.db.UserStore().GetUser(ctx, user.UserName)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := us.db.UserStore().GetBlockedUsers(ctx, user.UserName)
+	if err != nil {
+		return nil, err
+	}
+	excluded := map[string]bool{user.UserName: true}
+	for _, userName := range completeUserData.Follows {
+		excluded[userName] = true
+	}
+	for _, userName := range blocked {
+		excluded[userName] = true
+	}
+
+	// candidate -> people the user follows who follow the candidate
+	mutuals := make(map[string][]string)
+	var firstErr error
+	mtx := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	wg.Add(len(completeUserData.Follows))
+	for _, followingName := range completeUserData.Follows {
+		go func(followingName string) {
+			defer wg.Done()
+			following, err := us.db.UserStore().GetUser(ctx, followingName)
+			mtx.Lock()
+			defer mtx.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for _, candidate := range following.Follows {
+				if !excluded[candidate] {
+					mutuals[candidate] = append(mutuals[candidate], followingName)
+				}
+			}
+		}(followingName)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	suggestions := make([]*models.UserSuggestion, 0, len(mutuals))
+	for candidate, candidateMutuals := range mutuals {
+		sort.Strings(candidateMutuals)
+		suggestion := &models.UserSuggestion{
+			UserName:    candidate,
+			MutualCount: int32(len(candidateMutuals)),
+			Mutuals:     candidateMutuals,
+		}
+		if len(suggestion.Mutuals) > maxNamedMutuals {
+			suggestion.Mutuals = suggestion.Mutuals[:maxNamedMutuals]
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.Slice(suggestions, func(i int, j int) bool {
+		if suggestions[i].MutualCount != suggestions[j].MutualCount {
+			return suggestions[i].MutualCount > suggestions[j].MutualCount
+		}
+		return suggestions[i].UserName < suggestions[j].UserName
+	})
+
+	// the people who blocked the user are only looked up for the suggestions that make the cut
+	allowed := make([]*models.UserSuggestion, 0, limit)
+	for _, suggestion := range suggestions {
+		if len(allowed) == limit {
+			break
+		}
+		blockedBy, err := us.blocks(ctx, suggestion.UserName, user.UserName)
+		if err != nil {
+			return nil, err
+		}
+		if !blockedBy {
+			allowed = append(allowed, suggestion)
+		}
+	}
+	return allowed, nil
 }
 
 func New(as auth.Service, db storage.Storage) Service {
@@ -0,0 +1,110 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/twitter/models"
+	"github.com/twitter/storage"
+	"github.com/twitter/storage/memory"
+)
+
+func TestUserService_SuggestUsers(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	defer db.Close()
+	test_service := New(nil, db)
+	for _, userName := range []string{"me", "friend1", "friend2", "popular", "niche", "followed", "blocked", "blocker"} {
+		db.UserStore().AddUser(ctx, &models.User{UserName: userName})
+	}
+	follow := func(from string, to string) {
+		db.UserStore().FollowUser(ctx, &models.User{UserName: from}, &models.User{UserName: to})
+	}
+	follow("me", "friend1")
+	follow("me", "friend2")
+	follow("me", "followed")
+	follow("friend1", "popular")
+	follow("friend2", "popular")
+	follow("friend1", "niche")
+	follow("friend1", "followed")
+	follow("friend2", "blocked")
+	follow("friend2", "me")
+	follow("friend1", "blocker")
+	follow("friend2", "blocker")
+	db.UserStore().BlockUser(ctx, "me", "blocked")
+	db.UserStore().BlockUser(ctx, "blocker", "me")
+
+	suggestions, err := test_service.SuggestUsers(ctx, &models.User{UserName: "me"}, 10)
+	if err != nil {
+		t.Errorf("Error in suggesting users: %+v\n", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("Unexpected suggestions: %+v\n", suggestions)
+	}
+	if suggestions[0].UserName != "popular" || suggestions[0].MutualCount != 2 {
+		t.Errorf("Most connected user not suggested first: %+v\n", suggestions[0])
+	}
+	if suggestions[1].UserName != "niche" || suggestions[1].MutualCount != 1 || suggestions[1].Mutuals[0] != "friend1" {
+		t.Errorf("Unexpected second suggestion: %+v\n", suggestions[1])
+	}
+
+	suggestions, _ = test_service.SuggestUsers(ctx, &models.User{UserName: "me"}, 1)
+	if len(suggestions) != 1 || suggestions[0].UserName != "popular" {
+		t.Errorf("Limit not applied: %+v\n", suggestions)
+	}
+
+	unreachable_service := New(nil, unreachableUser{db, "friend2"})
+	if _, err := unreachable_service.SuggestUsers(ctx, &models.User{UserName: "me"}, 10); err == nil {
+		t.Error("Suggestions made without the people followed by a user who couldn't be read")
+	}
+}
+
+// unreachableUser is a storage where one of the users can't be read, like etcd timing out
+type unreachableUser struct {
+	storage.Storage
+	userName string
+}
+
+func (u unreachableUser) UserStore() storage.UserStore {
+	return failingUserStore{u.Storage.UserStore(), u.userName}
+}
+
+type failingUserStore struct {
+	storage.UserStore
+	userName string
+}
+
+func (f failingUserStore) GetUser(ctx context.Context, userName string) (*models.User, error) {
+	if userName == f.userName {
+		return nil, errors.New("context deadline exceeded")
+	}
+	return f.UserStore.GetUser(ctx, userName)
+}
+
+func TestUserService_FollowUser(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	defer db.Close()
+	test_service := New(nil, db)
+	for _, userName := range []string{"me", "blocked", "blocker", "friend"} {
+		db.UserStore().AddUser(ctx, &models.User{UserName: userName})
+	}
+	db.UserStore().BlockUser(ctx, "me", "blocked")
+	db.UserStore().BlockUser(ctx, "blocker", "me")
+
+	me := &models.User{UserName: "me"}
+	if err := test_service.FollowUser(ctx, me, &models.User{UserName: "blocked"}); !errors.Is(err, ErrBlocked) {
+		t.Errorf("Followed a blocked user: %+v\n", err)
+	}
+	if err := test_service.FollowUser(ctx, me, &models.User{UserName: "blocker"}); !errors.Is(err, ErrBlocked) {
+		t.Errorf("Followed a user who blocked the follower: %+v\n", err)
+	}
+	if err := test_service.FollowUser(ctx, me, &models.User{UserName: "friend"}); err != nil {
+		t.Errorf("Error in following user: %+v\n", err)
+	}
+	user, _ := db.UserStore().GetUser(ctx, "me")
+	if len(user.Follows) != 1 || user.Follows[0] != "friend" {
+		t.Errorf("Unexpected follows: %+v\n", user.Follows)
+	}
+}
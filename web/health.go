@@ -0,0 +1,39 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/twitter/twitter"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+// backendStatus asks the twitter service whether it is serving, UNKNOWN if it can't be asked
+func (ws *WebService) backendStatus(r *http.Request) healthpb.HealthCheckResponse_ServingStatus {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+	resp, err := ws.HealthService.Check(ctx, &healthpb.HealthCheckRequest{Service: twitter.Twitter_ServiceDesc.ServiceName})
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN
+	}
+	return resp.Status
+}
+
+// Healthz reports the web server alive as long as it answers, along with the status of the backend.
+// A backend that isn't serving doesn't make the web server worth restarting.
+func (ws *WebService) Healthz(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "ok\nbackend: %s\n", ws.backendStatus(r))
+}
+
+// Readyz reports the web server ready to take traffic only while the backend is serving
+func (ws *WebService) Readyz(w http.ResponseWriter, r *http.Request) {
+	status := ws.backendStatus(r)
+	if status != healthpb.HealthCheckResponse_SERVING {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	fmt.Fprintf(w, "backend: %s\n", status)
+}
@@ -4,13 +4,26 @@ import (
 	"context"
 	"fmt"
 	"html/template"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/twitter/idempotency"
+	"github.com/twitter/logging"
+	"github.com/twitter/media"
 	"github.com/twitter/models"
+	"github.com/twitter/polls"
 	"github.com/twitter/twitter"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Service interface {
@@ -25,28 +38,171 @@ type Service interface {
 	DeleteFollowing(w http.ResponseWriter, r *http.Request)
 	Logout(w http.ResponseWriter, r *http.Request)
 	Index(w http.ResponseWriter, r *http.Request)
+	Search(w http.ResponseWriter, r *http.Request)
+	Media(w http.ResponseWriter, r *http.Request)
+	EditPost(w http.ResponseWriter, r *http.Request)
 }
 
 type WebService struct {
 	TwitterService twitter.TwitterClient
+	HealthService  healthpb.HealthClient
 }
 
 type HomeContext struct {
-	Username  string
-	Posts     []map[string]string
-	Following int
-	Followers int
+	Username    string
+	Posts       []map[string]interface{}
+	Following   int
+	Followers   int
+	Suggestions []*models.UserSuggestion
+	Ranked      bool
+	// IdempotencyKey is sent with the post form, so that the post isn't created twice when the form is resubmitted
+	IdempotencyKey string
 }
 
 type ProfileContext struct {
 	Username     string
-	Posts        []map[string]string
+	Posts        []map[string]interface{}
 	FollowingNum int
 	FollowersNum int
 	Following    []string
 	Followers    []string
 }
 
+type SearchContext struct {
+	Query      string
+	Posts      []map[string]interface{}
+	TotalPosts int32
+	Users      []string
+	TotalUsers int32
+	Page       int
+	PrevPage   int
+	NextPage   int
+}
+
+type BookmarksContext struct {
+	Posts    []map[string]interface{}
+	Total    int32
+	PrevPage int
+	NextPage int
+}
+
+const (
+	searchPageSize    = 10
+	suggestionCount   = 5
+	bookmarksPageSize = 10
+	// uploads larger than this are buffered to temporary files while parsing the form
+	maxUploadMemory = 1 << 20
+)
+
+// postToMap flattens a post into the fields the templates render
+func postToMap(post *models.Post) map[string]interface{} {
+	editedAt := ""
+	if post.EditedAt != nil {
+		editedAt = post.EditedAt.AsTime().Format("15:04, Jan 2, 2006")
+	}
+	expiresAt := ""
+	if post.ExpiresAt != nil {
+		expiresAt = post.ExpiresAt.AsTime().Format("15:04, Jan 2, 2006")
+	}
+	return map[string]interface{}{
+		"editedAt":     editedAt,
+		"expiresAt":    expiresAt,
+		"author":       post.PostedBy,
+		"content":      post.Content,
+		"createdAt":    post.PostedAt.AsTime().Format("15:04, Jan 2, 2006"),
+		"postId":       post.PostID,
+		"imageURL":     post.ImageURL,
+		"mediumURL":    post.MediumURL,
+		"thumbnailURL": post.ThumbnailURL,
+		"poll":         pollToView(post),
+		"replyTo":      post.ReplyTo,
+		"replyToUser":  post.ReplyToUser,
+		"repostedBy":   post.RepostedBy,
+	}
+}
+
+type ListsContext struct {
+	Owner   string
+	IsOwner bool
+	Lists   []*models.UserList
+}
+
+type ListContext struct {
+	List    *models.UserList
+	IsOwner bool
+	Posts   []map[string]interface{}
+}
+
+type PollOptionView struct {
+	Index   int
+	Text    string
+	Votes   int64
+	Percent int64
+	Voted   bool
+}
+
+type PollView struct {
+	PostID         string
+	Options        []PollOptionView
+	ClosesAt       string
+	Closed         bool
+	HasVoted       bool
+	ResultsVisible bool
+	TotalVotes     int64
+}
+
+// pollToView prepares the poll of the post for the templates, nil if the post has no poll
+func pollToView(post *models.Post) *PollView {
+	if post.Poll == nil {
+		return nil
+	}
+	view := &PollView{
+		PostID:         post.PostID,
+		Options:        make([]PollOptionView, 0, len(post.Poll.Options)),
+		ClosesAt:       post.Poll.ClosesAt.AsTime().Format("15:04, Jan 2, 2006"),
+		Closed:         post.Poll.Closed,
+		HasVoted:       post.Poll.HasVoted,
+		ResultsVisible: post.Poll.ResultsVisible,
+		TotalVotes:     post.Poll.TotalVotes,
+	}
+	for idx, option := range post.Poll.Options {
+		optionView := PollOptionView{
+			Index: idx,
+			Text:  option.Text,
+			Votes: option.Votes,
+			Voted: post.Poll.HasVoted && int(post.Poll.VotedOption) == idx,
+		}
+		if post.Poll.TotalVotes > 0 {
+			optionView.Percent = option.Votes * 100 / post.Poll.TotalVotes
+		}
+		view.Options = append(view.Options, optionView)
+	}
+	return view
+}
+
+// profilePostsToMaps flattens the posts of the profile, marking the pinned one
+func profilePostsToMaps(profile *models.UserProfile) []map[string]interface{} {
+	postMaps := make([]map[string]interface{}, 0, len(profile.Posts))
+	for _, post := range profile.Posts {
+		postMap := postToMap(post)
+		if post.PostID == profile.PinnedPostID {
+			postMap["pinned"] = true
+		}
+		postMaps = append(postMaps, postMap)
+	}
+	return postMaps
+}
+
+// withIdempotencyKey passes the idempotency key of the form on to the service, which replays the response of the
+// first submission to the repeated ones
+func withIdempotencyKey(ctx context.Context, r *http.Request) context.Context {
+	key := r.Form.Get("idempotencyKey")
+	if key == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, idempotency.Key, key)
+}
+
 func (ws *WebService) getContextWithToken(r *http.Request) (context.Context, error) {
 	tokenCookie, err := r.Cookie("token")
 	if err != nil {
@@ -57,11 +213,36 @@ func (ws *WebService) getContextWithToken(r *http.Request) (context.Context, err
 	return newContext, nil
 }
 
+// uploadMedia streams the media to the twitter service in chunks
+func (ws *WebService) uploadMedia(ctx context.Context, contentType string, data io.Reader) (*models.Media, error) {
+	stream, err := ws.TwitterService.UploadMedia(ctx)
+	if err != nil {
+		return nil, err
+	}
+	buffer := make([]byte, media.ChunkSize)
+	for {
+		n, err := data.Read(buffer)
+		if n > 0 {
+			if sendErr := stream.Send(&models.MediaChunk{ContentType: contentType, Data: buffer[:n]}); sendErr != nil {
+				// the actual error is returned by CloseAndRecv
+				break
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
 func (ws *WebService) Login(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "GET" {
 		t, err := template.ParseFiles("web/login.gtpl")
 		if err != nil {
-			fmt.Println(err)
+			logging.FromContext(r.Context()).Error("Unable to parse template", "err", err)
 			http.Redirect(w, r, "/", http.StatusPermanentRedirect)
 			return
 		}
@@ -96,16 +277,16 @@ func (ws *WebService) Login(w http.ResponseWriter, r *http.Request) {
 func (ws *WebService) Register(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "GET" {
 		t, _ := template.ParseFiles("web/register.gtpl")
-		t.Execute(w, nil)
+		t.Execute(w, map[string]string{"IdempotencyKey": uuid.New().String()})
 	} else {
 		r.ParseForm()
-		_, err := ws.TwitterService.RegisterUser(r.Context(), &models.User{
+		_, err := ws.TwitterService.RegisterUser(withIdempotencyKey(r.Context(), r), &models.User{
 			UserName:     r.Form.Get("username"),
 			UserPassword: r.Form.Get("password"),
 		})
 		if err != nil {
 			fmt.Fprintf(w, "Register Failed : %s", err)
-			fmt.Println("error:", err)
+			logging.FromContext(r.Context()).Warn("Unable to register user", "err", err)
 		} else {
 			http.Redirect(w, r, "/login", http.StatusFound)
 		}
@@ -125,26 +306,34 @@ func (ws *WebService) Home(w http.ResponseWriter, r *http.Request) {
 			fmt.Fprintf(w, err.Error())
 			return
 		}
-		AllPosts := []map[string]string{}
+		AllPosts := []map[string]interface{}{}
 
-		posts, err := ws.TwitterService.GetFeed(newContext, &models.Empty{})
+		feedRequest := &models.FeedRequest{Order: models.FeedOrder_CHRONOLOGICAL}
+		if r.URL.Query().Get("order") == "top" {
+			feedRequest.Order = models.FeedOrder_RANKED
+		}
+		posts, err := ws.TwitterService.GetFeed(newContext, feedRequest)
 		if err != nil {
 			fmt.Fprintf(w, err.Error())
 			return
 		} else {
 			for _, post := range posts.Posts {
-				AllPosts = append(AllPosts, map[string]string{
-					"author":    post.PostedBy,
-					"content":   post.Content,
-					"createdAt": post.PostedAt.AsTime().Format("15:04, Jan 2, 2006"),
-				})
+				AllPosts = append(AllPosts, postToMap(post))
 			}
 		}
+		suggestions, err := ws.TwitterService.SuggestUsers(newContext, &models.SuggestUsersRequest{Limit: suggestionCount})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
 		context := HomeContext{
-			Username:  self.UserName,
-			Posts:     AllPosts,
-			Following: len(self.Follows),
-			Followers: len(self.Followers),
+			Username:       self.UserName,
+			Posts:          AllPosts,
+			Following:      len(self.Follows),
+			Followers:      len(self.Followers),
+			Suggestions:    suggestions.Suggestions,
+			Ranked:         feedRequest.Order == models.FeedOrder_RANKED,
+			IdempotencyKey: uuid.New().String(),
 		}
 		err = t.Execute(w, context)
 		if err != nil {
@@ -167,25 +356,39 @@ func (ws *WebService) Profile(w http.ResponseWriter, r *http.Request) {
 			fmt.Fprintf(w, err.Error())
 			return
 		}
-		selfProfile, err := ws.TwitterService.GetMyPosts(newContext, &models.Empty{})
+		selfProfile, err := ws.TwitterService.GetUserProfile(newContext, &models.User{UserName: self.UserName})
 		if err != nil {
 			fmt.Fprintf(w, err.Error())
 			return
 		}
 
-		AllPosts := []map[string]string{}
+		AllPosts := []map[string]interface{}{}
 
 		if err != nil {
 			fmt.Fprintf(w, err.Error())
 			return
 		} else {
-			for _, post := range selfProfile.Posts {
-				AllPosts = append(AllPosts, map[string]string{
-					"author":    post.PostedBy,
-					"content":   post.Content,
-					"createdAt": post.PostedAt.AsTime().Format("15:04, Jan 2, 2006"),
-					"postId":    post.PostID,
-				})
+			AllPosts = profilePostsToMaps(selfProfile)
+		}
+		// stats are only available for the user's own posts, not for the posts they reposted
+		ownPostIds := make([]string, 0, len(selfProfile.Posts))
+		for _, post := range selfProfile.Posts {
+			if post.PostedBy == self.UserName {
+				ownPostIds = append(ownPostIds, post.PostID)
+			}
+		}
+		postStats, err := ws.TwitterService.GetPostStats(newContext, &models.PostStatsRequest{PostIDs: ownPostIds})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		statsById := make(map[string]*models.PostStats, len(postStats.Stats))
+		for _, stats := range postStats.Stats {
+			statsById[stats.PostID] = stats
+		}
+		for _, postMap := range AllPosts {
+			if stats, exists := statsById[postMap["postId"].(string)]; exists {
+				postMap["stats"] = stats
 			}
 		}
 		context := ProfileContext{
@@ -226,20 +429,13 @@ func (ws *WebService) OtherUser(w http.ResponseWriter, r *http.Request) {
 
 		self := userProfile.User
 
-		AllPosts := []map[string]string{}
+		AllPosts := []map[string]interface{}{}
 
 		if err != nil {
 			fmt.Fprintf(w, err.Error())
 			return
 		} else {
-			for _, post := range userProfile.Posts {
-				AllPosts = append(AllPosts, map[string]string{
-					"author":    post.PostedBy,
-					"content":   post.Content,
-					"createdAt": post.PostedAt.AsTime().Format("15:04, Jan 2, 2006"),
-					"postId":    post.PostID,
-				})
-			}
+			AllPosts = profilePostsToMaps(userProfile)
 		}
 		context := ProfileContext{
 			Username:     self.UserName,
@@ -285,11 +481,48 @@ func (ws *WebService) CreatePost(w http.ResponseWriter, r *http.Request) {
 			fmt.Fprintf(w, "Status Unauthorized")
 			return
 		}
-		r.ParseForm()
+		r.ParseMultipartForm(maxUploadMemory)
 		post := models.Post{
 			Content: r.Form.Get("content"),
+			ReplyTo: r.Form.Get("replyTo"),
+		}
+		pollOptions := make([]*models.PollOption, 0, polls.MaxOptions)
+		for idx := 1; idx <= polls.MaxOptions; idx++ {
+			if option := strings.TrimSpace(r.Form.Get(fmt.Sprintf("option%d", idx))); option != "" {
+				pollOptions = append(pollOptions, &models.PollOption{Text: option})
+			}
+		}
+		if len(pollOptions) > 0 {
+			pollHours, err := strconv.Atoi(r.Form.Get("pollHours"))
+			if err != nil {
+				fmt.Fprintf(w, "Invalid poll duration")
+				return
+			}
+			post.Poll = &models.Poll{
+				Options:  pollOptions,
+				ClosesAt: timestamppb.New(time.Now().Add(time.Duration(pollHours) * time.Hour)),
+			}
+		}
+		if ttl := r.Form.Get("ttl"); ttl != "" {
+			post.TTLSeconds, err = strconv.ParseInt(ttl, 10, 64)
+			if err != nil {
+				fmt.Fprintf(w, "Invalid post lifetime")
+				return
+			}
 		}
-		_, err = ws.TwitterService.CreatePost(newContext, &post)
+		imageFile, imageHeader, err := r.FormFile("image")
+		if err == nil {
+			defer imageFile.Close()
+			uploadedMedia, err := ws.uploadMedia(newContext, imageHeader.Header.Get("Content-Type"), imageFile)
+			if err != nil {
+				fmt.Fprintf(w, err.Error())
+				return
+			}
+			post.ImageURL = uploadedMedia.URL
+			post.MediumURL = uploadedMedia.MediumURL
+			post.ThumbnailURL = uploadedMedia.ThumbnailURL
+		}
+		_, err = ws.TwitterService.CreatePost(withIdempotencyKey(newContext, r), &post)
 		if err != nil {
 			fmt.Fprintf(w, err.Error())
 			return
@@ -298,6 +531,27 @@ func (ws *WebService) CreatePost(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (ws *WebService) EditPost(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		post := models.Post{
+			PostID:  r.Form.Get("postId"),
+			Content: r.Form.Get("content"),
+		}
+		_, err = ws.TwitterService.EditPost(newContext, &post)
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/profile", http.StatusFound)
+	}
+}
+
 func (ws *WebService) FollowUser(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "POST" {
 		r.ParseForm()
@@ -338,6 +592,40 @@ func (ws *WebService) DeleteFollowing(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (ws *WebService) BlockUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		r.ParseForm()
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		_, err = ws.TwitterService.BlockUser(newContext, &models.User{UserName: r.Form.Get("username")})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/home", http.StatusFound)
+	}
+}
+
+func (ws *WebService) UnBlockUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		r.ParseForm()
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		_, err = ws.TwitterService.UnBlockUser(newContext, &models.User{UserName: r.Form.Get("username")})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/home", http.StatusFound)
+	}
+}
+
 func (ws *WebService) Logout(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "POST" {
 		http.SetCookie(w, &http.Cookie{
@@ -358,3 +646,427 @@ func (ws *WebService) Index(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/home", http.StatusFound)
 	}
 }
+
+func (ws *WebService) Search(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		t, _ := template.ParseFiles("web/search.gtpl")
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		query := r.URL.Query().Get("q")
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil || page < 1 {
+			page = 1
+		}
+		context := SearchContext{
+			Query: query,
+			Posts: []map[string]interface{}{},
+			Users: []string{},
+			Page:  page,
+		}
+		if query != "" {
+			results, err := ws.TwitterService.Search(newContext, &models.SearchRequest{
+				Query:  query,
+				Offset: int32((page - 1) * searchPageSize),
+				Limit:  searchPageSize,
+			})
+			if err != nil {
+				fmt.Fprintf(w, err.Error())
+				return
+			}
+			for _, post := range results.Posts {
+				context.Posts = append(context.Posts, postToMap(post))
+			}
+			for _, user := range results.Users {
+				context.Users = append(context.Users, user.UserName)
+			}
+			context.TotalPosts = results.TotalPosts
+			context.TotalUsers = results.TotalUsers
+			if page > 1 {
+				context.PrevPage = page - 1
+			}
+			if int32(page*searchPageSize) < results.TotalPosts || int32(page*searchPageSize) < results.TotalUsers {
+				context.NextPage = page + 1
+			}
+		}
+		err = t.Execute(w, context)
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+	}
+}
+
+func (ws *WebService) Bookmarks(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		t, _ := template.ParseFiles("web/bookmarks.gtpl")
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil || page < 1 {
+			page = 1
+		}
+		bookmarks, err := ws.TwitterService.ListBookmarks(newContext, &models.BookmarksRequest{
+			Offset: int32((page - 1) * bookmarksPageSize),
+			Limit:  bookmarksPageSize,
+		})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		context := BookmarksContext{
+			Posts: []map[string]interface{}{},
+			Total: bookmarks.Total,
+		}
+		for _, post := range bookmarks.Posts {
+			context.Posts = append(context.Posts, postToMap(post))
+		}
+		if page > 1 {
+			context.PrevPage = page - 1
+		}
+		if int32(page*bookmarksPageSize) < bookmarks.Total {
+			context.NextPage = page + 1
+		}
+		err = t.Execute(w, context)
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+	}
+}
+
+func (ws *WebService) Repost(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		_, err = ws.TwitterService.Repost(newContext, &models.Post{PostID: r.Form.Get("postId")})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/home", http.StatusFound)
+	}
+}
+
+func (ws *WebService) UnRepost(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		_, err = ws.TwitterService.UnRepost(newContext, &models.Post{PostID: r.Form.Get("postId")})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/home", http.StatusFound)
+	}
+}
+
+func (ws *WebService) BookmarkPost(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		_, err = ws.TwitterService.BookmarkPost(newContext, &models.Post{PostID: r.Form.Get("postId")})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/bookmarks", http.StatusFound)
+	}
+}
+
+func (ws *WebService) RemoveBookmark(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		_, err = ws.TwitterService.RemoveBookmark(newContext, &models.Post{PostID: r.Form.Get("postId")})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/bookmarks", http.StatusFound)
+	}
+}
+
+func (ws *WebService) PinPost(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		_, err = ws.TwitterService.PinPost(newContext, &models.Post{PostID: r.Form.Get("postId")})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/profile", http.StatusFound)
+	}
+}
+
+func (ws *WebService) UnpinPost(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		_, err = ws.TwitterService.UnpinPost(newContext, &models.Empty{})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/profile", http.StatusFound)
+	}
+}
+
+func (ws *WebService) VotePoll(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		option, err := strconv.Atoi(r.Form.Get("option"))
+		if err != nil {
+			fmt.Fprintf(w, "Pick an option to vote for")
+			return
+		}
+		_, err = ws.TwitterService.VotePoll(newContext, &models.PollVote{
+			PostID: r.Form.Get("postId"),
+			Option: int32(option),
+		})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/home", http.StatusFound)
+	}
+}
+
+func (ws *WebService) Lists(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		t, _ := template.ParseFiles("web/lists.gtpl")
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		self, err := ws.TwitterService.GetSelf(newContext, &models.Empty{})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		owner := r.URL.Query().Get("user")
+		if owner == "" {
+			owner = self.UserName
+		}
+		userLists, err := ws.TwitterService.GetUserLists(newContext, &models.User{UserName: owner})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		context := ListsContext{
+			Owner:   owner,
+			IsOwner: owner == self.UserName,
+			Lists:   userLists.Lists,
+		}
+		err = t.Execute(w, context)
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+	}
+}
+
+func (ws *WebService) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		t, _ := template.ParseFiles("web/list.gtpl")
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		self, err := ws.TwitterService.GetSelf(newContext, &models.Empty{})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		listId := r.URL.Query().Get("id")
+		list, err := ws.TwitterService.GetList(newContext, &models.UserList{ListID: listId})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		timeline, err := ws.TwitterService.GetListTimeline(newContext, &models.UserList{ListID: listId})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		context := ListContext{
+			List:    list,
+			IsOwner: list.Owner == self.UserName,
+			Posts:   []map[string]interface{}{},
+		}
+		for _, post := range timeline.Posts {
+			context.Posts = append(context.Posts, postToMap(post))
+		}
+		err = t.Execute(w, context)
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+	}
+}
+
+func (ws *WebService) CreateList(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		createdList, err := ws.TwitterService.CreateList(newContext, &models.UserList{
+			Name:    r.Form.Get("name"),
+			Private: r.Form.Get("private") == "on",
+		})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/list?id="+url.QueryEscape(createdList.ListID), http.StatusFound)
+	}
+}
+
+func (ws *WebService) UpdateList(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		listId := r.Form.Get("listId")
+		_, err = ws.TwitterService.UpdateList(newContext, &models.UserList{
+			ListID:  listId,
+			Name:    r.Form.Get("name"),
+			Private: r.Form.Get("private") == "on",
+		})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/list?id="+url.QueryEscape(listId), http.StatusFound)
+	}
+}
+
+func (ws *WebService) DeleteList(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		_, err = ws.TwitterService.DeleteList(newContext, &models.UserList{ListID: r.Form.Get("listId")})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/lists", http.StatusFound)
+	}
+}
+
+func (ws *WebService) AddListMember(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		listId := r.Form.Get("listId")
+		_, err = ws.TwitterService.AddListMember(newContext, &models.ListMember{
+			ListID:   listId,
+			UserName: r.Form.Get("username"),
+		})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/list?id="+url.QueryEscape(listId), http.StatusFound)
+	}
+}
+
+func (ws *WebService) RemoveListMember(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		newContext, err := ws.getContextWithToken(r)
+		if err != nil {
+			fmt.Fprintf(w, "Status Unauthorized")
+			return
+		}
+		r.ParseForm()
+		listId := r.Form.Get("listId")
+		_, err = ws.TwitterService.RemoveListMember(newContext, &models.ListMember{
+			ListID:   listId,
+			UserName: r.Form.Get("username"),
+		})
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+			return
+		}
+		http.Redirect(w, r, "/list?id="+url.QueryEscape(listId), http.StatusFound)
+	}
+}
+
+func (ws *WebService) Media(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		mediaId := strings.TrimPrefix(r.URL.Path, media.URLPrefix)
+		stream, err := ws.TwitterService.GetMedia(r.Context(), &models.Media{MediaID: mediaId})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		chunk, err := stream.Recv()
+		if status.Code(err) == codes.NotFound {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", chunk.ContentType)
+		// media is stored under the hash of its content, so the content never changes
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		for err == nil {
+			if _, writeErr := w.Write(chunk.Data); writeErr != nil {
+				return
+			}
+			chunk, err = stream.Recv()
+		}
+	}
+}